@@ -0,0 +1,232 @@
+package eventbrite
+
+import (
+	"container/list"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"golang.org/x/net/context"
+)
+
+const (
+	// DefaultCacheSize is the number of responses kept by the cache of WithCache(nil)
+	DefaultCacheSize = 256
+	// DefaultCacheTTL is the time responses are served by the cache of WithCache(nil) before
+	// being revalidated
+	DefaultCacheTTL = 24 * time.Hour
+)
+
+// cachedPaths lists the prefixes of the reference data paths whose GET responses are cached.
+// Those change rarely and are the same for every token.
+var cachedPaths = []string{
+	"/categories",
+	"/subcategories",
+	"/formats",
+	"/system/",
+	"/pricing/fee_rates",
+}
+
+// CacheEntry is a cached response body along with its ETag
+type CacheEntry struct {
+	Body []byte
+	ETag string
+}
+
+// Cache stores the GET responses of reference data, keyed by path and query
+type Cache interface {
+	// Get returns the entry stored under key, if any, and whether it is still fresh. A stale
+	// entry with an ETag is revalidated with an If-None-Match request.
+	Get(key string) (entry *CacheEntry, fresh bool)
+	// Set stores the entry under key, replacing any previous one
+	Set(key string, entry *CacheEntry)
+	// Delete removes the entry stored under key
+	Delete(key string)
+}
+
+// WithCache configures a Eventbrite client to cache the responses of reference data endpoints
+// like Categories, Formats, Timezones or FeeRate. A nil cache uses an LRU cache of
+// DefaultCacheSize entries fresh for DefaultCacheTTL.
+func WithCache(cache Cache) ClientOption {
+	return func(c *Client) error {
+		if cache == nil {
+			cache = NewLRUCache(DefaultCacheSize, DefaultCacheTTL)
+		}
+		c.cache = cache
+		return nil
+	}
+}
+
+type cacheControl int
+
+const (
+	cacheDefault cacheControl = iota
+	// the request neither reads nor stores the cache
+	cacheSkip
+	// the request drops the cached entry and stores the new response
+	cacheRefresh
+)
+
+type cacheControlKey struct{}
+
+// NoCache returns a context whose requests bypass the client cache, they neither read nor
+// store cached responses
+func NoCache(ctx context.Context) context.Context {
+	return context.WithValue(ctx, cacheControlKey{}, cacheSkip)
+}
+
+// RefreshCache returns a context whose requests invalidate the cached response and store the
+// fresh one
+func RefreshCache(ctx context.Context) context.Context {
+	return context.WithValue(ctx, cacheControlKey{}, cacheRefresh)
+}
+
+func cacheControlFrom(ctx context.Context) cacheControl {
+	cc, _ := ctx.Value(cacheControlKey{}).(cacheControl)
+	return cc
+}
+
+func (c *Client) cacheable(path string) bool {
+	if c.cache == nil {
+		return false
+	}
+	for _, prefix := range cachedPaths {
+		if strings.HasPrefix(path, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+// getCachedJSON is getJSON for cacheable paths. Fresh entries are served without a request,
+// stale ones are revalidated using their ETag.
+func (c *Client) getCachedJSON(ctx context.Context, op, path string, apiReq interface{}, resp interface{}) error {
+	cc := cacheControlFrom(ctx)
+	if cc == cacheSkip {
+		return c.getJSONWithHeader(ctx, op, path, apiReq, nil, resp)
+	}
+
+	values, err := encodeQuery(apiReq)
+	if err != nil {
+		return err
+	}
+
+	key := path + "?" + values.Encode() + "#" + c.expandFor(ctx, http.MethodGet, resp).String()
+	if cc == cacheRefresh {
+		c.cache.Delete(key)
+	}
+
+	entry, fresh := c.cache.Get(key)
+	if entry != nil && fresh {
+		return json.Unmarshal(entry.Body, resp)
+	}
+
+	header := http.Header{}
+	if entry != nil && entry.ETag != "" {
+		header.Set("If-None-Match", entry.ETag)
+	}
+
+	r, attempts, err := c.get(ctx, op, path, apiReq, header, resp)
+	if err != nil {
+		return withAttempts(attempts, err)
+	}
+
+	if r.StatusCode == http.StatusNotModified {
+		if entry == nil {
+			return fmt.Errorf("eventbrite: GET %s: unexpected %s", path, http.StatusText(r.StatusCode))
+		}
+		c.cache.Set(key, entry)
+		return json.Unmarshal(entry.Body, resp)
+	}
+
+	// a response made up by a middleware has no body to cache
+	if r.body != nil {
+		c.cache.Set(key, &CacheEntry{Body: r.body, ETag: r.Header.Get("ETag")})
+	}
+	return nil
+}
+
+// LRUCache is an in-memory Cache keeping a bounded number of entries, evicting the least
+// recently used one when full. Entries are fresh for a fixed TTL after being set, stale ones
+// are kept for revalidation until evicted.
+type LRUCache struct {
+	size int
+	ttl  time.Duration
+
+	mu      sync.Mutex
+	ll      *list.List
+	entries map[string]*list.Element
+}
+
+type lruItem struct {
+	key     string
+	entry   *CacheEntry
+	expires time.Time
+}
+
+// NewLRUCache returns an LRUCache holding up to size entries fresh for ttl
+func NewLRUCache(size int, ttl time.Duration) *LRUCache {
+	return &LRUCache{
+		size:    size,
+		ttl:     ttl,
+		ll:      list.New(),
+		entries: map[string]*list.Element{},
+	}
+}
+
+// Get implements Cache
+func (c *LRUCache) Get(key string) (*CacheEntry, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	el, ok := c.entries[key]
+	if !ok {
+		return nil, false
+	}
+	c.ll.MoveToFront(el)
+
+	item := el.Value.(*lruItem)
+	return item.entry, time.Now().Before(item.expires)
+}
+
+// Set implements Cache
+func (c *LRUCache) Set(key string, entry *CacheEntry) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	item := &lruItem{key: key, entry: entry, expires: time.Now().Add(c.ttl)}
+	if el, ok := c.entries[key]; ok {
+		el.Value = item
+		c.ll.MoveToFront(el)
+		return
+	}
+
+	c.entries[key] = c.ll.PushFront(item)
+	for c.size > 0 && c.ll.Len() > c.size {
+		oldest := c.ll.Back()
+		c.ll.Remove(oldest)
+		delete(c.entries, oldest.Value.(*lruItem).key)
+	}
+}
+
+// Delete implements Cache
+func (c *LRUCache) Delete(key string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if el, ok := c.entries[key]; ok {
+		c.ll.Remove(el)
+		delete(c.entries, key)
+	}
+}
+
+// Len returns the number of entries in the cache
+func (c *LRUCache) Len() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return c.ll.Len()
+}
@@ -0,0 +1,164 @@
+package eventbrite
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"golang.org/x/net/context"
+)
+
+func TestLRUCache(t *testing.T) {
+	entry := func(body string) *CacheEntry { return &CacheEntry{Body: []byte(body)} }
+
+	tests := []struct {
+		name string
+		ttl  time.Duration
+		run  func(c *LRUCache)
+		// the expected body and freshness of every key, absent keys are missing
+		want map[string]bool
+	}{
+		{
+			name: "fresh",
+			ttl:  time.Hour,
+			run:  func(c *LRUCache) { c.Set("a", entry("a")) },
+			want: map[string]bool{"a": true},
+		},
+		{
+			name: "stale",
+			ttl:  -time.Second,
+			run:  func(c *LRUCache) { c.Set("a", entry("a")) },
+			want: map[string]bool{"a": false},
+		},
+		{
+			name: "evicts the least recently set",
+			ttl:  time.Hour,
+			run: func(c *LRUCache) {
+				c.Set("a", entry("a"))
+				c.Set("b", entry("b"))
+				c.Set("c", entry("c"))
+			},
+			want: map[string]bool{"b": true, "c": true},
+		},
+		{
+			name: "evicts the least recently read",
+			ttl:  time.Hour,
+			run: func(c *LRUCache) {
+				c.Set("a", entry("a"))
+				c.Set("b", entry("b"))
+				c.Get("a")
+				c.Set("c", entry("c"))
+			},
+			want: map[string]bool{"a": true, "c": true},
+		},
+		{
+			name: "replace",
+			ttl:  time.Hour,
+			run: func(c *LRUCache) {
+				c.Set("a", entry("old"))
+				c.Set("b", entry("b"))
+				c.Set("a", entry("a"))
+				c.Set("c", entry("c"))
+			},
+			want: map[string]bool{"a": true, "c": true},
+		},
+		{
+			name: "delete",
+			ttl:  time.Hour,
+			run: func(c *LRUCache) {
+				c.Set("a", entry("a"))
+				c.Set("b", entry("b"))
+				c.Delete("a")
+				c.Delete("missing")
+			},
+			want: map[string]bool{"b": true},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := NewLRUCache(2, tt.ttl)
+			tt.run(c)
+
+			if c.Len() != len(tt.want) {
+				t.Errorf("Len() = %d, want %d", c.Len(), len(tt.want))
+			}
+			for _, key := range []string{"a", "b", "c"} {
+				wantFresh, want := tt.want[key]
+				got, fresh := c.Get(key)
+				switch {
+				case !want && got != nil:
+					t.Errorf("Get(%s) = %s, want none", key, got.Body)
+				case want && (got == nil || string(got.Body) != key):
+					t.Errorf("Get(%s) = %v, want %s", key, got, key)
+				case want && fresh != wantFresh:
+					t.Errorf("Get(%s) fresh = %v, want %v", key, fresh, wantFresh)
+				}
+			}
+		})
+	}
+}
+
+func TestCacheRevalidation(t *testing.T) {
+	tests := []struct {
+		name string
+		ttl  time.Duration
+		ctx  func(context.Context) context.Context
+		// the If-None-Match header of the second request, "-" when no request is sent
+		wantIfNoneMatch string
+		wantLocale      string
+	}{
+		{name: "fresh", ttl: time.Hour, wantIfNoneMatch: "-", wantLocale: "v1"},
+		{name: "stale not modified", ttl: -time.Second, wantIfNoneMatch: `"v1"`, wantLocale: "v1"},
+		{name: "no cache", ttl: time.Hour, ctx: NoCache, wantLocale: "v2"},
+		{name: "refresh", ttl: time.Hour, ctx: RefreshCache, wantLocale: "v2"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var requests []string
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				inm := r.Header.Get("If-None-Match")
+				requests = append(requests, inm)
+				if inm == `"v1"` {
+					w.WriteHeader(http.StatusNotModified)
+					return
+				}
+				version := fmt.Sprintf("v%d", len(requests))
+				w.Header().Set("ETag", `"`+version+`"`)
+				fmt.Fprintf(w, `{"locale":%q,"categories":[{"id":"103"}]}`, version)
+			}))
+			defer srv.Close()
+
+			clnt, err := NewClient(WithToken("token"), WithBaseURL(srv.URL), WithRateLimit(0), WithCache(NewLRUCache(10, tt.ttl)))
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if _, err := clnt.Categories(context.Background()); err != nil {
+				t.Fatal(err)
+			}
+			ctx := context.Background()
+			if tt.ctx != nil {
+				ctx = tt.ctx(ctx)
+			}
+			res, err := clnt.Categories(ctx)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if res.Locale != tt.wantLocale || len(res.Categories) != 1 {
+				t.Errorf("second response = %+v, want locale %s", res, tt.wantLocale)
+			}
+			gotIfNoneMatch := "-"
+			if len(requests) > 1 {
+				gotIfNoneMatch = requests[1]
+			}
+			if gotIfNoneMatch != tt.wantIfNoneMatch {
+				t.Errorf("second request If-None-Match = %s, want %s", gotIfNoneMatch, tt.wantIfNoneMatch)
+			}
+		})
+	}
+}
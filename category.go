@@ -22,7 +22,7 @@ type SubCategoriesResult struct {
 func (c *Client) Categories(ctx context.Context) (*CategoriesResult, error) {
 	result := new(CategoriesResult)
 
-	return result, c.getJSON(ctx, "/categories", nil, &result)
+	return result, c.getJSON(ctx, "Categories", "/categories", nil, &result)
 }
 
 // Category gets a category by ID as category
@@ -31,7 +31,7 @@ func (c *Client) Categories(ctx context.Context) (*CategoriesResult, error) {
 func (c *Client) Category(ctx context.Context, id string) (*Category, error) {
 	result := new(Category)
 
-	return result, c.getJSON(ctx, "/categories/"+id, nil, &result)
+	return result, c.getJSON(ctx, "Category", "/categories/"+id, nil, &result)
 }
 
 // SubCategories gets a list of subcategory as subcategories
@@ -40,7 +40,7 @@ func (c *Client) Category(ctx context.Context, id string) (*Category, error) {
 func (c *Client) SubCategories(ctx context.Context) (*SubCategoriesResult, error) {
 	result := new(SubCategoriesResult)
 
-	return result, c.getJSON(ctx, "/subcategories/", nil, &result)
+	return result, c.getJSON(ctx, "SubCategories", "/subcategories/", nil, &result)
 }
 
 // SubCategory gets a subcategory by ID as subcategory
@@ -48,7 +48,7 @@ func (c *Client) SubCategories(ctx context.Context) (*SubCategoriesResult, error
 // https://www.eventbrite.com/developer/v3/endpoints/categories/#ebapi-get-subcategories-id
 func (c *Client) SubCategory(ctx context.Context, id string) (*SubCategory, error) {
 	result := &SubCategory{}
-	if err := c.getJSON(ctx, "/subcategories/"+id, nil, &result); err != nil {
+	if err := c.getJSON(ctx, "SubCategory", "/subcategories/"+id, nil, &result); err != nil {
 		return nil, err
 	}
 	return result, nil
@@ -0,0 +1,447 @@
+// Package checkin runs the door of an event: it preloads the attendees and their barcodes, then
+// validates scanned barcodes offline, records the check-ins in a local journal and reconciles
+// them with the API once connectivity returns.
+//
+//	d := checkin.NewDoor(clnt, "123", "door-1", checkin.NewFileJournal("door-1.jsonl"))
+//	if err := d.Preload(ctx); err != nil {
+//		// handle me
+//	}
+//
+//	s, err := d.Scan(ctx, barcode)
+//	if s.Result != checkin.Admitted {
+//		// turn them away
+//	}
+//
+// Several devices scanning the same event exchange their check-ins with Merge. When two devices
+// admitted the same barcode, the earliest check-in is kept and a Conflict reports the other one,
+// so every device ends up with the same state whatever the order of the merges.
+package checkin
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/apzuk3/go-eventbrite"
+
+	"golang.org/x/net/context"
+)
+
+// DefaultOverlap is how far before the most recent change seen each reconciliation starts, to
+// catch attendees whose change was committed after a more recent one had been listed
+const DefaultOverlap = time.Minute
+
+// changedSinceLayout is the format of the changed_since parameter
+const changedSinceLayout = "2006-01-02T15:04:05Z"
+
+// Barcode statuses, as returned in AttendeeBarcodes.Status
+const (
+	StatusUnused   = "unused"
+	StatusUsed     = "used"
+	StatusRefunded = "refunded"
+)
+
+// Result is the outcome of a scan
+type Result string
+
+// Results of a scan
+const (
+	// The barcode is valid and the attendee has been checked in
+	Admitted Result = "admitted"
+	// The barcode has already been checked in, by this device, another one or on Eventbrite
+	AlreadyUsed Result = "already_used"
+	// The ticket has been refunded
+	Refunded Result = "refunded"
+	// The attendee has been cancelled
+	Cancelled Result = "cancelled"
+	// The barcode does not belong to the event
+	Unknown Result = "unknown"
+)
+
+// Reason explains a conflict
+type Reason string
+
+// Reasons of a conflict
+const (
+	// Two devices checked in the same barcode
+	ReasonDuplicate Reason = "duplicate"
+	// The ticket was refunded on Eventbrite after or while it was checked in
+	ReasonRefunded Reason = "refunded"
+	// The attendee was cancelled on Eventbrite after or while they were checked in
+	ReasonCancelled Reason = "cancelled"
+)
+
+// Ticket is a barcode of an attendee as last known from the API
+type Ticket struct {
+	Barcode         string
+	AttendeeID      string
+	OrderID         string
+	Name            string
+	TicketClassName string
+	// The barcode status: unused, used or refunded
+	Status string
+	// Whether the attendee has been cancelled
+	Cancelled bool
+	// When the attendee was last changed
+	Changed time.Time
+}
+
+// CheckIn is the admission of a barcode by a device
+type CheckIn struct {
+	EventID    string    `json:"event_id"`
+	Barcode    string    `json:"barcode"`
+	AttendeeID string    `json:"attendee_id"`
+	Device     string    `json:"device"`
+	Time       time.Time `json:"time"`
+	// Whether the API reported the attendee as checked in since, or Push succeeded
+	Confirmed bool `json:"confirmed"`
+}
+
+// same reports whether both values are the same check-in, whatever their confirmation
+func (c CheckIn) same(o CheckIn) bool {
+	return c.Barcode == o.Barcode && c.Device == o.Device && c.Time.Equal(o.Time)
+}
+
+// Conflict is a check-in that could not be honoured
+type Conflict struct {
+	Reason Reason
+	// The check-in kept, the earliest one for a duplicate
+	CheckIn CheckIn
+	// The check-in discarded for a duplicate
+	Duplicate *CheckIn
+}
+
+// Scan is the outcome of scanning a barcode
+type Scan struct {
+	Result Result
+	// The ticket of the barcode, nil when unknown
+	Ticket *Ticket
+	// The check-in recorded when admitted, the earlier one when already used on a device
+	CheckIn *CheckIn
+}
+
+// Door validates the barcodes of an event. The exported fields must be set before the preload.
+type Door struct {
+	client  *eventbrite.Client
+	eventID string
+	device  string
+	journal Journal
+
+	// Overlap is how far before the most recent change seen each reconciliation starts
+	Overlap time.Duration
+	// Push, when set, reports to Eventbrite a check-in the API does not know about yet. The
+	// public API does not expose check-ins, so it is left to the integration; without it
+	// Reconcile only confirms the check-ins seen through the attendees list.
+	Push func(ctx context.Context, c CheckIn) error
+
+	mu           sync.Mutex
+	tickets      map[string]*Ticket
+	checkIns     map[string]CheckIn
+	conflicts    map[string]Conflict
+	changedSince time.Time
+}
+
+// NewDoor returns a Door for the event, recording its check-ins in the journal under the given
+// device name, which must be unique among the devices scanning the event
+func NewDoor(client *eventbrite.Client, eventID, device string, journal Journal) *Door {
+	return &Door{
+		client:    client,
+		eventID:   eventID,
+		device:    device,
+		journal:   journal,
+		Overlap:   DefaultOverlap,
+		tickets:   map[string]*Ticket{},
+		checkIns:  map[string]CheckIn{},
+		conflicts: map[string]Conflict{},
+	}
+}
+
+// Preload lists every attendee of the event and replays the journal. It must succeed once before
+// scanning, and may be called again to start over from a fresh copy of the attendees.
+func (d *Door) Preload(ctx context.Context) error {
+	attendees, err := d.list(ctx, "")
+	if err != nil {
+		return err
+	}
+	checkIns, err := d.journal.Load(ctx, d.eventID)
+	if err != nil {
+		return fmt.Errorf("checkin: load journal: %v", err)
+	}
+
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	d.tickets = map[string]*Ticket{}
+	d.checkIns = map[string]CheckIn{}
+	d.conflicts = map[string]Conflict{}
+	d.changedSince = time.Time{}
+	for _, a := range attendees {
+		d.index(a)
+	}
+	for _, c := range checkIns {
+		d.apply(c)
+	}
+	return nil
+}
+
+// Scan validates a barcode and checks its attendee in when valid. The check-in is appended to
+// the journal before the attendee is reported admitted.
+func (d *Door) Scan(ctx context.Context, barcode string) (Scan, error) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	t, ok := d.tickets[barcode]
+	if !ok {
+		return Scan{Result: Unknown}, nil
+	}
+	ticket := *t
+
+	if c, ok := d.checkIns[barcode]; ok {
+		return Scan{Result: AlreadyUsed, Ticket: &ticket, CheckIn: &c}, nil
+	}
+	switch {
+	case ticket.Cancelled:
+		return Scan{Result: Cancelled, Ticket: &ticket}, nil
+	case ticket.Status == StatusRefunded:
+		return Scan{Result: Refunded, Ticket: &ticket}, nil
+	case ticket.Status == StatusUsed:
+		return Scan{Result: AlreadyUsed, Ticket: &ticket}, nil
+	}
+
+	c := CheckIn{
+		EventID:    d.eventID,
+		Barcode:    barcode,
+		AttendeeID: ticket.AttendeeID,
+		Device:     d.device,
+		Time:       time.Now().UTC(),
+	}
+	if err := d.journal.Append(ctx, c); err != nil {
+		return Scan{}, fmt.Errorf("checkin: append journal: %v", err)
+	}
+	d.checkIns[barcode] = c
+	return Scan{Result: Admitted, Ticket: &ticket, CheckIn: &c}, nil
+}
+
+// Lookup returns the ticket of a barcode and its check-in, without checking it in
+func (d *Door) Lookup(barcode string) (*Ticket, *CheckIn) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	t, ok := d.tickets[barcode]
+	if !ok {
+		return nil, nil
+	}
+	ticket := *t
+	if c, ok := d.checkIns[barcode]; ok {
+		return &ticket, &c
+	}
+	return &ticket, nil
+}
+
+// CheckIns returns the check-ins known to the door, recorded locally or merged, ordered by time.
+// They are what a device sends to the others to merge.
+func (d *Door) CheckIns() []CheckIn {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	res := make([]CheckIn, 0, len(d.checkIns))
+	for _, c := range d.checkIns {
+		res = append(res, c)
+	}
+	sort.Slice(res, func(i, j int) bool { return res[i].Time.Before(res[j].Time) })
+	return res
+}
+
+// Conflicts returns every conflict detected since the preload
+func (d *Door) Conflicts() []Conflict {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	res := make([]Conflict, 0, len(d.conflicts))
+	for _, c := range d.conflicts {
+		res = append(res, c)
+	}
+	sort.Slice(res, func(i, j int) bool { return res[i].CheckIn.Time.Before(res[j].CheckIn.Time) })
+	return res
+}
+
+// Merge applies the check-ins of other devices, appending the new ones to the journal, and
+// returns the conflicts they caused. Check-ins of other events are ignored.
+func (d *Door) Merge(ctx context.Context, checkIns []CheckIn) ([]Conflict, error) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	var res []Conflict
+	for _, c := range checkIns {
+		if c.EventID != d.eventID {
+			continue
+		}
+		if cur, ok := d.checkIns[c.Barcode]; ok && cur.same(c) && (cur.Confirmed || !c.Confirmed) {
+			continue
+		}
+
+		if err := d.journal.Append(ctx, c); err != nil {
+			return res, fmt.Errorf("checkin: append journal: %v", err)
+		}
+		if conflict := d.apply(c); conflict != nil {
+			res = append(res, *conflict)
+		}
+	}
+	return res, nil
+}
+
+// Reconcile lists the attendees changed since the preload or the previous reconciliation, then
+// confirms the unconfirmed check-ins, pushing them when the door has a Push function. It returns
+// the check-ins of tickets refunded or cancelled in the meantime as conflicts.
+func (d *Door) Reconcile(ctx context.Context) ([]Conflict, error) {
+	d.mu.Lock()
+	var since string
+	if !d.changedSince.IsZero() {
+		since = d.changedSince.Add(-d.Overlap).UTC().Format(changedSinceLayout)
+	}
+	d.mu.Unlock()
+
+	attendees, err := d.list(ctx, since)
+	if err != nil {
+		return nil, err
+	}
+
+	d.mu.Lock()
+	for _, a := range attendees {
+		d.index(a)
+	}
+
+	var res []Conflict
+	var pending []CheckIn
+	for barcode, c := range d.checkIns {
+		if c.Confirmed {
+			continue
+		}
+
+		t := d.tickets[barcode]
+		switch {
+		case t == nil:
+			continue
+		case t.Cancelled:
+			res = append(res, d.conflict(Conflict{Reason: ReasonCancelled, CheckIn: c}))
+		case t.Status == StatusRefunded:
+			res = append(res, d.conflict(Conflict{Reason: ReasonRefunded, CheckIn: c}))
+		case t.Status == StatusUsed:
+			c.Confirmed = true
+			if err := d.journal.Append(ctx, c); err != nil {
+				d.mu.Unlock()
+				return res, fmt.Errorf("checkin: append journal: %v", err)
+			}
+			d.checkIns[barcode] = c
+		default:
+			pending = append(pending, c)
+		}
+	}
+	d.mu.Unlock()
+
+	if d.Push == nil {
+		return res, nil
+	}
+	for _, c := range pending {
+		if err := d.Push(ctx, c); err != nil {
+			return res, err
+		}
+
+		c.Confirmed = true
+		if err := d.confirm(ctx, c); err != nil {
+			return res, err
+		}
+	}
+	return res, nil
+}
+
+// confirm records a pushed check-in as confirmed, unless it lost a conflict meanwhile
+func (d *Door) confirm(ctx context.Context, c CheckIn) error {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	if cur, ok := d.checkIns[c.Barcode]; !ok || !cur.same(c) {
+		return nil
+	}
+	if err := d.journal.Append(ctx, c); err != nil {
+		return fmt.Errorf("checkin: append journal: %v", err)
+	}
+	d.checkIns[c.Barcode] = c
+	return nil
+}
+
+// list walks the attendees of the event changed since the given time, every attendee when empty
+func (d *Door) list(ctx context.Context, since string) ([]eventbrite.Attendee, error) {
+	var res []eventbrite.Attendee
+	err := d.client.EventAttendeesIterator(ctx, d.eventID, &eventbrite.EventGetAttendees{ChangedSince: since}).Walk(func(a eventbrite.Attendee) error {
+		res = append(res, a)
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("checkin: list attendees: %w", err)
+	}
+	return res, nil
+}
+
+// index replaces the tickets of an attendee, d.mu must be held
+func (d *Door) index(a eventbrite.Attendee) {
+	if a.Changed.Time.After(d.changedSince) {
+		d.changedSince = a.Changed.Time
+	}
+
+	for _, b := range a.Barcodes {
+		if b.Barcode == "" {
+			continue
+		}
+
+		status := b.Status
+		if a.Refunded {
+			status = StatusRefunded
+		}
+		d.tickets[b.Barcode] = &Ticket{
+			Barcode:         b.Barcode,
+			AttendeeID:      a.ID,
+			OrderID:         a.OrderID,
+			Name:            a.Profile.Name,
+			TicketClassName: a.TicketClassName,
+			Status:          status,
+			Cancelled:       a.Cancelled,
+			Changed:         a.Changed.Time,
+		}
+	}
+}
+
+// apply records a check-in read from the journal or merged, keeping the earliest one of a
+// barcode. It returns the conflict caused, if any. d.mu must be held.
+func (d *Door) apply(c CheckIn) *Conflict {
+	cur, ok := d.checkIns[c.Barcode]
+	switch {
+	case !ok:
+		d.checkIns[c.Barcode] = c
+		return nil
+	case cur.same(c):
+		cur.Confirmed = cur.Confirmed || c.Confirmed
+		d.checkIns[c.Barcode] = cur
+		return nil
+	}
+
+	kept, dup := cur, c
+	if c.Time.Before(cur.Time) || c.Time.Equal(cur.Time) && c.Device < cur.Device {
+		kept, dup = c, cur
+	}
+	d.checkIns[c.Barcode] = kept
+
+	conflict := d.conflict(Conflict{Reason: ReasonDuplicate, CheckIn: kept, Duplicate: &dup})
+	return &conflict
+}
+
+// conflict records a conflict once, d.mu must be held
+func (d *Door) conflict(c Conflict) Conflict {
+	key := string(c.Reason) + ":" + c.CheckIn.Barcode + ":" + c.CheckIn.Device + ":" + c.CheckIn.Time.String()
+	if c.Duplicate != nil {
+		key += ":" + c.Duplicate.Device + ":" + c.Duplicate.Time.String()
+	}
+	d.conflicts[key] = c
+	return c
+}
@@ -0,0 +1,178 @@
+package checkin
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"sync"
+
+	"golang.org/x/net/context"
+)
+
+// Journal persists the check-ins recorded or merged by a door, so that a restarted device keeps
+// refusing the barcodes already scanned. Records are appended, a later record of the same
+// barcode, device and time replaces an earlier one.
+type Journal interface {
+	// Load returns every check-in appended for the event, in the order they were appended
+	Load(ctx context.Context, eventID string) ([]CheckIn, error)
+	// Append stores a check-in
+	Append(ctx context.Context, c CheckIn) error
+}
+
+// MemoryJournal is a Journal keeping the check-ins in memory, they are lost along with the process
+type MemoryJournal struct {
+	mu       sync.Mutex
+	checkIns []CheckIn
+}
+
+// NewMemoryJournal returns an empty MemoryJournal
+func NewMemoryJournal() *MemoryJournal {
+	return &MemoryJournal{}
+}
+
+// Load implements Journal
+func (j *MemoryJournal) Load(_ context.Context, eventID string) ([]CheckIn, error) {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+
+	res := []CheckIn{}
+	for _, c := range j.checkIns {
+		if c.EventID == eventID {
+			res = append(res, c)
+		}
+	}
+	return res, nil
+}
+
+// Append implements Journal
+func (j *MemoryJournal) Append(_ context.Context, c CheckIn) error {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+
+	j.checkIns = append(j.checkIns, c)
+	return nil
+}
+
+// FileJournal is a Journal appending the check-ins to a file, one JSON object per line. Each
+// record is synced to disk before Append returns, so an admitted attendee is never forgotten
+// after a crash.
+type FileJournal struct {
+	path string
+	mu   sync.Mutex
+}
+
+// NewFileJournal returns a FileJournal reading and writing the file at path, which is created on
+// the first append
+func NewFileJournal(path string) *FileJournal {
+	return &FileJournal{path: path}
+}
+
+// Load implements Journal. A truncated last line, left by a crash during an append, is ignored.
+// Any other line which cannot be decoded fails the load, as skipping it would forget a check-in.
+func (j *FileJournal) Load(_ context.Context, eventID string) ([]CheckIn, error) {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+
+	res := []CheckIn{}
+
+	f, err := os.Open(j.path)
+	if os.IsNotExist(err) {
+		return res, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	r := bufio.NewReader(f)
+	for n := 1; ; n++ {
+		line, err := r.ReadBytes('\n')
+		if err == io.EOF {
+			// an unterminated line is an interrupted append, dropped by the next one
+			return res, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		if len(bytes.TrimSpace(line)) == 0 {
+			continue
+		}
+
+		var c CheckIn
+		if err := json.Unmarshal(line, &c); err != nil {
+			return nil, fmt.Errorf("%s:%d: corrupt check-in: %v", j.path, n, err)
+		}
+		if c.EventID == eventID {
+			res = append(res, c)
+		}
+	}
+}
+
+// Append implements Journal
+func (j *FileJournal) Append(_ context.Context, c CheckIn) error {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+
+	b, err := json.Marshal(c)
+	if err != nil {
+		return err
+	}
+
+	f, err := os.OpenFile(j.path, os.O_CREATE|os.O_RDWR, 0600)
+	if err != nil {
+		return err
+	}
+	if err := dropPartialLine(f); err != nil {
+		f.Close()
+		return err
+	}
+
+	if _, err := f.Write(append(b, '\n')); err != nil {
+		f.Close()
+		return err
+	}
+	if err := f.Sync(); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
+
+// dropPartialLine truncates the unterminated line a crash may have left at the end of the
+// journal, and moves the offset of f to its end
+func dropPartialLine(f *os.File) error {
+	size, err := f.Seek(0, io.SeekEnd)
+	if err != nil || size == 0 {
+		return err
+	}
+
+	buf := make([]byte, 4096)
+	end := size
+	for end > 0 {
+		start := end - int64(len(buf))
+		if start < 0 {
+			start = 0
+		}
+		chunk := buf[:end-start]
+		if _, err := f.ReadAt(chunk, start); err != nil {
+			return err
+		}
+		if i := bytes.LastIndexByte(chunk, '\n'); i >= 0 {
+			end = start + int64(i) + 1
+			break
+		}
+		end = start
+	}
+	if end == size {
+		return nil
+	}
+
+	if err := f.Truncate(end); err != nil {
+		return err
+	}
+	_, err = f.Seek(end, io.SeekStart)
+	return err
+}
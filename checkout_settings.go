@@ -138,7 +138,7 @@ type CheckoutAssociatePayoutToEvent struct {
 func (c *Client) CheckoutGetList(ctx context.Context) (*Checkout, error) {
 	s := new(Checkout)
 
-	return s, c.getJSON(ctx, "/checkout_settings/countries_currencies/", nil, s)
+	return s, c.getJSON(ctx, "CheckoutGetList", "/checkout_settings/countries_currencies/", nil, s)
 }
 
 // CheckoutMethods gets the available checkout methods to do payments given a country and a currency
@@ -147,7 +147,7 @@ func (c *Client) CheckoutGetList(ctx context.Context) (*Checkout, error) {
 func (c *Client) CheckoutMethods(ctx context.Context, req CheckoutMethodsRequest) (*CheckoutMethodsResponse, error) {
 	s := new(CheckoutMethodsResponse)
 
-	return s, c.getJSON(ctx, "/checkout_settings/methods/", nil, s)
+	return s, c.getJSON(ctx, "CheckoutMethods", "/checkout_settings/methods/", nil, s)
 }
 
 // CheckoutForAccount searches and returns a list of checkout_settings for the current
@@ -157,7 +157,7 @@ func (c *Client) CheckoutMethods(ctx context.Context, req CheckoutMethodsRequest
 func (c *Client) CheckoutForAccount(ctx context.Context, req *CheckoutForAccountRequest) (*CheckoutSettingsForAccount, error) {
 	s := new(CheckoutSettingsForAccount)
 
-	return s, c.getJSON(ctx, "/checkout_settings/", nil, s)
+	return s, c.getJSON(ctx, "CheckoutForAccount", "/checkout_settings/", nil, s)
 }
 
 // CheckoutCreate creates a new checkout_settings object belonging to the current user. Two
@@ -173,7 +173,7 @@ func (c *Client) CheckoutForAccount(ctx context.Context, req *CheckoutForAccount
 func (c *Client) CheckoutCreate(ctx context.Context, req *CheckoutCreateRequest) (*Checkout, error) {
 	s := new(Checkout)
 
-	return s, c.postJSON(ctx, "/checkout_settings/", req, s)
+	return s, c.postJSON(ctx, "CheckoutCreate", "/checkout_settings/", req, s)
 }
 
 // CheckoutGet gets a specific checkout_settings object by ID
@@ -182,7 +182,7 @@ func (c *Client) CheckoutCreate(ctx context.Context, req *CheckoutCreateRequest)
 func (c *Client) CheckoutGet(ctx context.Context, id string) (*Checkout, error) {
 	s := new(Checkout)
 
-	return s, c.getJSON(ctx, fmt.Sprintf("/checkout_settings/%s/", id), nil, s)
+	return s, c.getJSON(ctx, "CheckoutGet", fmt.Sprintf("/checkout_settings/%s/", id), nil, s)
 }
 
 // CheckoutByEvent gets and returns a list of checkout_settings associated with a given event by its event_id
@@ -191,7 +191,7 @@ func (c *Client) CheckoutGet(ctx context.Context, id string) (*Checkout, error)
 func (c *Client) CheckoutByEvent(ctx context.Context, eventId string) ([]*Checkout, error) {
 	var s []*Checkout
 
-	return s, c.getJSON(ctx, fmt.Sprintf("/events/%s/checkout_settings/", eventId), nil, s)
+	return s, c.getJSON(ctx, "CheckoutByEvent", fmt.Sprintf("/events/%s/checkout_settings/", eventId), nil, s)
 }
 
 // CheckoutAssociate associates a single or set of checkout_settings with a given event by its event_id. This does not add
@@ -202,7 +202,7 @@ func (c *Client) CheckoutByEvent(ctx context.Context, eventId string) ([]*Checko
 func (c *Client) CheckoutAssociate(ctx context.Context, eventID string, req *CheckoutAssociateToEventRequest) (interface{}, error) {
 	var v interface{}
 
-	return v, c.postJSON(ctx, fmt.Sprintf("/events/%s/checkout_settings/", eventID), req, v)
+	return v, c.postJSON(ctx, "CheckoutAssociate", fmt.Sprintf("/events/%s/checkout_settings/", eventID), req, v)
 }
 
 // Associates a payout user instrument ID with a given event, or clear the association by
@@ -215,6 +215,6 @@ func (c *Client) CheckoutAssociatePayoutSettings(
 	req *CheckoutAssociatePayoutToEvent) (interface{}, error) {
 	var v interface{}
 
-	return v, c.postJSON(ctx, fmt.Sprintf("/events/%s/checkout_settings/", eventID), req, v)
+	return v, c.postJSON(ctx, "CheckoutAssociatePayoutSettings", fmt.Sprintf("/events/%s/checkout_settings/", eventID), req, v)
 
 }
@@ -4,14 +4,15 @@ import (
 	"bytes"
 	"encoding/json"
 	"errors"
+	"fmt"
+	"io"
 	"net/http"
 	"net/url"
 	"reflect"
-	"strconv"
-	"time"
+	"strings"
 
 	"golang.org/x/net/context"
-	"golang.org/x/net/context/ctxhttp"
+	"golang.org/x/oauth2"
 
 	"gopkg.in/go-playground/validator.v9"
 )
@@ -27,7 +28,14 @@ type Client struct {
 	token             string
 	baseURL           string
 	requestsPerSecond int
-	ratePerSecond     chan int
+	limiter           RateLimiter
+	ownsLimiter       bool
+	retryPolicy       RetryPolicy
+	cache             Cache
+	tokenSource       oauth2.TokenSource
+	expand            Expand
+	middlewares       []Middleware
+	observers         []CallObserver
 }
 
 // ClientOption is the type of constructor options for NewClient(...).
@@ -39,6 +47,7 @@ func NewClient(options ...ClientOption) (*Client, error) {
 
 	WithBaseURL("https://www.eventbriteapi.com/v3")(c)
 	WithRateLimit(defaultRequestsPerSecond)(c)
+	WithExpand(DefaultExpand...)(c)
 	WithHTTPClient(&http.Client{})(c)
 
 	for _, option := range options {
@@ -48,17 +57,9 @@ func NewClient(options ...ClientOption) (*Client, error) {
 		}
 	}
 
-	if c.requestsPerSecond > 0 {
-		c.ratePerSecond = make(chan int, c.requestsPerSecond)
-		for i := 0; i < c.requestsPerSecond; i++ {
-			c.ratePerSecond <- 1
-		}
-		go func() {
-			time.Sleep(time.Second)
-			for range time.Tick(time.Second / time.Duration(c.requestsPerSecond)) {
-				c.ratePerSecond <- 1
-			}
-		}()
+	if c.limiter == nil && c.requestsPerSecond > 0 {
+		c.limiter = NewTokenBucket(float64(c.requestsPerSecond), c.requestsPerSecond)
+		c.ownsLimiter = true
 	}
 
 	return c, nil
@@ -89,196 +90,229 @@ func WithBaseURL(baseURL string) ClientOption {
 }
 
 // WithRateLimit configures the rate limit for back end requests. Default is to
-// limit to 50 requests per second. A value of zero disables rate limiting.
+// limit to 5 requests per second, with bursts of as many requests. A value of zero
+// disables rate limiting, a negative one is an error. The limiter is owned by the client and
+// closed along with it.
 func WithRateLimit(requestsPerSecond int) ClientOption {
 	return func(c *Client) error {
+		if requestsPerSecond < 0 {
+			return fmt.Errorf("eventbrite: negative rate limit %d", requestsPerSecond)
+		}
 		c.requestsPerSecond = requestsPerSecond
+		c.limiter = nil
+		c.ownsLimiter = false
 		return nil
 	}
 }
 
-func (c *Client) awaitRateLimiter(ctx context.Context) error {
-	if c.ratePerSecond == nil {
-		return nil
-	}
-	select {
-	case <-ctx.Done():
-		return ctx.Err()
-	case <-c.ratePerSecond:
+// WithRateLimiter configures the client to pace its requests with l, which may be shared
+// with other clients using the same token so that they stay within its limits together.
+// The limiter is not closed by Client.Close. A nil limiter disables rate limiting.
+//
+//	limiter := eventbrite.NewTokenBucket(5, 10)
+//	defer limiter.Close()
+//
+//	clnt1, err := eventbrite.NewClient(eventbrite.WithToken(token), eventbrite.WithRateLimiter(limiter))
+//	clnt2, err := eventbrite.NewClient(eventbrite.WithToken(token), eventbrite.WithRateLimiter(limiter))
+func WithRateLimiter(l RateLimiter) ClientOption {
+	return func(c *Client) error {
+		c.limiter = l
+		c.ownsLimiter = false
+		c.requestsPerSecond = 0
 		return nil
 	}
 }
 
-func (c *Client) get(ctx context.Context, path string, apiReq interface{}) (*http.Response, error) {
-	if err := c.awaitRateLimiter(ctx); err != nil {
-		return nil, err
-	}
-
-	if apiReq != nil {
-		if err := validate.Struct(apiReq); err != nil {
-			return nil, err
-		}
-	}
+// RateLimiter returns the limiter pacing the requests of the client, nil when they are not
+// rate limited. The limiter created by WithRateLimit is a *TokenBucket, whose Stats may be
+// used for monitoring.
+func (c *Client) RateLimiter() RateLimiter {
+	return c.limiter
+}
 
-	host := path
-	if c.baseURL != "" {
-		host = c.baseURL
-	}
-	req, err := http.NewRequest(http.MethodGet, host+path, nil)
-	if err != nil {
-		return nil, err
+// Close releases the resources of the client: the rate limiter it owns, whose waiting requests
+// fail, and the idle connections of its HTTP client. The client must not be used afterwards.
+func (c *Client) Close() error {
+	var err error
+	if closer, ok := c.limiter.(io.Closer); ok && c.ownsLimiter {
+		err = closer.Close()
 	}
-	q, err := c.generateAuthQuery(path, toValues(apiReq))
-	if err != nil {
-		return nil, err
-	}
-	req.URL.RawQuery = q
-	return ctxhttp.Do(ctx, c.httpClient, req)
+	c.httpClient.CloseIdleConnections()
+	return err
 }
 
-func (c *Client) delete(ctx context.Context, path string) (*http.Response, error) {
-	if err := c.awaitRateLimiter(ctx); err != nil {
-		return nil, err
+func (c *Client) awaitRateLimiter(ctx context.Context) error {
+	if c.limiter == nil {
+		return nil
 	}
+	return c.limiter.Wait(ctx)
+}
 
-	host := path
-	if c.baseURL != "" {
-		host = c.baseURL
+func (c *Client) get(ctx context.Context, op, path string, apiReq interface{}, header http.Header, result interface{}) (*Response, int, error) {
+	if err := validateRequest(apiReq); err != nil {
+		return nil, 0, err
 	}
 
-	req, err := http.NewRequest(http.MethodDelete, host+path, nil)
+	values, err := encodeQuery(apiReq)
 	if err != nil {
-		return nil, err
+		return nil, 0, err
 	}
 
-	q, err := c.generateAuthQuery(path, url.Values{})
+	q, err := c.generateAuthQuery(ctx, http.MethodGet, values, result)
 	if err != nil {
-		return nil, err
+		return nil, 0, err
 	}
 
-	req.URL.RawQuery = q
-	return ctxhttp.Do(ctx, c.httpClient, req)
+	if header == nil {
+		header = http.Header{}
+	}
+	req := &Request{Method: http.MethodGet, Path: path, Operation: op, Params: apiReq, Header: header, Result: result}
+	return c.call(ctx, req, func() (*http.Request, error) {
+		req, err := http.NewRequest(http.MethodGet, c.url(path), nil)
+		if err != nil {
+			return nil, err
+		}
+		req.URL.RawQuery = q
+		return req, nil
+	})
 }
 
-func (c *Client) post(ctx context.Context, path string, apiReq interface{}) (*http.Response, error) {
+func (c *Client) delete(ctx context.Context, op, path string, result interface{}) (*Response, int, error) {
+	q, err := c.generateAuthQuery(ctx, http.MethodDelete, url.Values{}, result)
+	if err != nil {
+		return nil, 0, err
+	}
 
-	if apiReq != nil {
-		if err := validate.Struct(apiReq); err != nil {
+	req := &Request{Method: http.MethodDelete, Path: path, Operation: op, Header: http.Header{}, Result: result}
+	return c.call(ctx, req, func() (*http.Request, error) {
+		req, err := http.NewRequest(http.MethodDelete, c.url(path), nil)
+		if err != nil {
 			return nil, err
 		}
-	}
+		req.URL.RawQuery = q
+		return req, nil
+	})
+}
 
-	if err := c.awaitRateLimiter(ctx); err != nil {
-		return nil, err
-	}
+func (c *Client) post(ctx context.Context, op, path string, apiReq interface{}, result interface{}) (*Response, int, error) {
 
-	host := path
-	if c.baseURL != "" {
-		host = c.baseURL
+	if err := validateRequest(apiReq); err != nil {
+		return nil, 0, err
 	}
 
 	body, err := json.Marshal(apiReq)
 	if err != nil {
-		return nil, err
-	}
-	req, err := http.NewRequest(http.MethodPost, host+path, bytes.NewBuffer(body))
-	if err != nil {
-		return nil, err
+		return nil, 0, err
 	}
-	req.Header.Set("Content-Type", "application/json")
-	q, err := c.generateAuthQuery(path, url.Values{})
+	q, err := c.generateAuthQuery(ctx, http.MethodPost, url.Values{}, result)
 	if err != nil {
-		return nil, err
+		return nil, 0, err
 	}
 
-	req.URL.RawQuery = q
-	return ctxhttp.Do(ctx, c.httpClient, req)
+	req := &Request{Method: http.MethodPost, Path: path, Operation: op, Params: apiReq, Header: http.Header{}, Result: result}
+	return c.call(ctx, req, func() (*http.Request, error) {
+		req, err := http.NewRequest(http.MethodPost, c.url(path), bytes.NewReader(body))
+		if err != nil {
+			return nil, err
+		}
+		req.Header.Set("Content-Type", "application/json")
+		req.URL.RawQuery = q
+		return req, nil
+	})
 }
 
-func (c *Client) generateAuthQuery(path string, q url.Values) (string, error) {
-	if c.token != "" {
-		q.Set("token", c.token)
-		q.Set("expand", "venue,category,subcategories")
-		return q.Encode(), nil
+// observeRateLimit reports a HIT_RATE_LIMIT response to the limiter when it reacts to them
+func (c *Client) observeRateLimit(resp *http.Response) {
+	o, ok := c.limiter.(RateLimitObserver)
+	if !ok || resp == nil || resp.StatusCode != http.StatusTooManyRequests {
+		return
 	}
-	return "", errors.New("eventbrite: Token missing")
+
+	d, _ := retryAfter(resp.Header.Get("Retry-After"))
+	o.ObserveRateLimit(d)
 }
 
-func (c *Client) getJSON(ctx context.Context, path string, apiReq interface{}, resp interface{}) error {
-	httpResp, err := c.get(ctx, path, apiReq)
-	if err != nil {
-		return err
+// validateRequest validates the struct tags of apiReq. Raw query values and nil requests have
+// nothing to validate.
+func validateRequest(apiReq interface{}) error {
+	switch apiReq.(type) {
+	case nil, url.Values:
+		return nil
 	}
-	defer httpResp.Body.Close()
-
-	if httpResp.StatusCode == 200 {
-		return json.NewDecoder(httpResp.Body).Decode(resp)
+	if v := reflect.ValueOf(apiReq); v.Kind() == reflect.Ptr && v.IsNil() {
+		return nil
 	}
-
-	respErr := Error{}
-	json.NewDecoder(httpResp.Body).Decode(&respErr)
-	return respErr
+	return validate.Struct(apiReq)
 }
 
-func (c *Client) postJSON(ctx context.Context, path string, apiReq interface{}, resp interface{}) error {
-	httpResp, err := c.post(ctx, path, apiReq)
+// Resolve fetches the object behind an api_url returned by Eventbrite, like the one of a webhook
+// delivery, and decodes it into resp. The URL must live under the client base URL so the token is
+// never sent to another host.
+func (c *Client) Resolve(ctx context.Context, apiURL string, resp interface{}) error {
+	u, err := url.Parse(apiURL)
 	if err != nil {
 		return err
 	}
-	defer httpResp.Body.Close()
 
-	return json.NewDecoder(httpResp.Body).Decode(resp)
-}
+	query := u.RawQuery
+	u.RawQuery = ""
+	if !strings.HasPrefix(u.String(), c.baseURL+"/") {
+		return fmt.Errorf("eventbrite: %s is not an url of %s", apiURL, c.baseURL)
+	}
 
-func (c *Client) deleteJSON(ctx context.Context, path string, resp interface{}) error {
-	httpResp, err := c.delete(ctx, path)
+	q, err := url.ParseQuery(query)
 	if err != nil {
 		return err
 	}
-	defer httpResp.Body.Close()
+	return c.getJSON(ctx, "Resolve", strings.TrimPrefix(u.String(), c.baseURL), q, resp)
+}
 
-	return json.NewDecoder(httpResp.Body).Decode(resp)
+func (c *Client) url(path string) string {
+	if c.baseURL != "" {
+		return c.baseURL + path
+	}
+	return path
 }
 
-func toValues(i interface{}) (values url.Values) {
+// generateAuthQuery adds the token to the query, unless the client has a token source in which
+// case requests are authenticated through their Authorization header, along with the expansions
+// result can decode when the query does not already have some
+func (c *Client) generateAuthQuery(ctx context.Context, method string, q url.Values, result interface{}) (string, error) {
+	switch {
+	case c.tokenSource != nil:
+	case c.token != "":
+		q.Set("token", c.token)
+	default:
+		return "", errors.New("eventbrite: Token missing")
+	}
 
-	if i == nil {
-		return url.Values{}
+	if expand := c.expandFor(ctx, method, result).String(); expand != "" && q.Get("expand") == "" {
+		q.Set("expand", expand)
 	}
+	return q.Encode(), nil
+}
 
-	switch i.(type) {
-	case url.Values:
-		return i.(url.Values)
+func (c *Client) getJSON(ctx context.Context, op, path string, apiReq interface{}, resp interface{}) error {
+	if c.cacheable(path) {
+		return c.getCachedJSON(ctx, op, path, apiReq, resp)
 	}
+	return c.getJSONWithHeader(ctx, op, path, apiReq, nil, resp)
+}
 
-	values = url.Values{}
-	iVal := reflect.ValueOf(i).Elem()
-	typ := iVal.Type()
-	for i := 0; i < iVal.NumField(); i++ {
-		f := iVal.Field(i)
-		tag := typ.Field(i).Tag.Get("json")
-		if tag == "" {
-			tag = typ.Field(i).Name
-		}
-		var v string
-		switch f.Interface().(type) {
-		case int, int8, int16, int32, int64:
-			v = strconv.FormatInt(f.Int(), 10)
-		case uint, uint8, uint16, uint32, uint64:
-			v = strconv.FormatUint(f.Uint(), 10)
-		case float32:
-			v = strconv.FormatFloat(f.Float(), 'f', 4, 32)
-		case float64:
-			v = strconv.FormatFloat(f.Float(), 'f', 4, 64)
-		case []byte:
-			v = string(f.Bytes())
-		case string:
-			v = f.String()
-		}
+func (c *Client) getJSONWithHeader(ctx context.Context, op, path string, apiReq interface{}, header http.Header, resp interface{}) error {
+	_, attempts, err := c.get(ctx, op, path, apiReq, header, resp)
+	return withAttempts(attempts, err)
+}
 
-		if v != "" {
-			values.Set(tag, v)
-		}
+func (c *Client) postJSON(ctx context.Context, op, path string, apiReq interface{}, resp interface{}) error {
+	_, attempts, err := c.post(ctx, op, path, apiReq, resp)
+	if respErr, ok := err.(*Error); ok {
+		respErr.Fields = fieldErrors(respErr.Arguments, apiReq)
 	}
-	return
+	return withAttempts(attempts, err)
+}
+
+func (c *Client) deleteJSON(ctx context.Context, op, path string, resp interface{}) error {
+	_, attempts, err := c.delete(ctx, op, path, resp)
+	return withAttempts(attempts, err)
 }
@@ -0,0 +1,86 @@
+package main
+
+import (
+	"flag"
+	"strconv"
+
+	"github.com/apzuk3/go-eventbrite"
+
+	"golang.org/x/net/context"
+)
+
+var attendeeColumns = []column[eventbrite.Attendee]{
+	{"ID", func(a eventbrite.Attendee) string { return a.ID }},
+	{"NAME", func(a eventbrite.Attendee) string { return a.Profile.Name }},
+	{"EMAIL", func(a eventbrite.Attendee) string { return a.Profile.Email }},
+	{"TICKET_CLASS", func(a eventbrite.Attendee) string { return a.TicketClassName }},
+	{"STATUS", func(a eventbrite.Attendee) string { return a.Status }},
+	{"CHECKED_IN", func(a eventbrite.Attendee) string { return strconv.FormatBool(a.CheckedIn) }},
+	{"ORDER_ID", func(a eventbrite.Attendee) string { return a.OrderID }},
+}
+
+var orderColumns = []column[eventbrite.Order]{
+	{"ID", func(o eventbrite.Order) string { return o.ID }},
+	{"NAME", func(o eventbrite.Order) string { return o.Name }},
+	{"EMAIL", func(o eventbrite.Order) string { return o.Email }},
+	{"GROSS", func(o eventbrite.Order) string { return o.Costs.Gross.Display }},
+	{"EVENT_ID", func(o eventbrite.Order) string { return o.EventID }},
+	{"CREATED", func(o eventbrite.Order) string { return formatDateTime(o.Created) }},
+}
+
+func formatDateTime(d eventbrite.DateTime) string {
+	if d.Time.IsZero() {
+		return ""
+	}
+	return d.Time.Format("2006-01-02T15:04:05Z")
+}
+
+func listAttendees(fs *flag.FlagSet) runFunc {
+	req := &eventbrite.EventGetAttendees{}
+	fs.StringVar(&req.Status, "status", "", "attending, not_attending or unpaid")
+	fs.StringVar(&req.ChangedSince, "changed-since", "", "only attendees changed since this time, like 2006-01-02T15:04:05Z")
+	limit := fs.Int("limit", 0, "maximum number of attendees, 0 for all")
+
+	return func(ctx context.Context, e *env, args []string) error {
+		attendees, err := collect(e.client.EventAttendeesIterator(ctx, args[0], req), *limit)
+		if err != nil {
+			return err
+		}
+		return printList(e.out, attendees, attendeeColumns)
+	}
+}
+
+func getAttendee(fs *flag.FlagSet) runFunc {
+	return func(ctx context.Context, e *env, args []string) error {
+		attendee, err := e.client.EventAttendee(ctx, args[0], args[1])
+		if err != nil {
+			return err
+		}
+		return printOne(e.out, *attendee, attendeeColumns)
+	}
+}
+
+func listOrders(fs *flag.FlagSet) runFunc {
+	req := &eventbrite.EventGetOrders{}
+	fs.StringVar(&req.Status, "status", "", "active, inactive, both or all_not_deleted")
+	fs.StringVar(&req.ChangedSince, "changed-since", "", "only orders changed since this time, like 2006-01-02T15:04:05Z")
+	limit := fs.Int("limit", 0, "maximum number of orders, 0 for all")
+
+	return func(ctx context.Context, e *env, args []string) error {
+		orders, err := collect(e.client.EventOrdersIterator(ctx, args[0], req), *limit)
+		if err != nil {
+			return err
+		}
+		return printList(e.out, orders, orderColumns)
+	}
+}
+
+func getOrder(fs *flag.FlagSet) runFunc {
+	return func(ctx context.Context, e *env, args []string) error {
+		order, err := e.client.OrderGet(ctx, args[0])
+		if err != nil {
+			return err
+		}
+		return printOne(e.out, *order, orderColumns)
+	}
+}
@@ -0,0 +1,100 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/apzuk3/go-eventbrite"
+
+	"golang.org/x/net/context"
+)
+
+// env is what commands run with
+type env struct {
+	client *eventbrite.Client
+	out    *printer
+}
+
+// runFunc runs a command with its positional arguments
+type runFunc func(ctx context.Context, e *env, args []string) error
+
+// command is a subcommand of the tool, addressed by resource and name
+type command struct {
+	resource string
+	name     string
+	// usage of the positional arguments
+	args string
+	// number of positional arguments
+	nargs int
+	help  string
+	// setup registers the flags of the command and returns the function running it
+	setup func(fs *flag.FlagSet) runFunc
+}
+
+var commands = []command{
+	{"events", "search", "", 0, "search public events", searchEvents},
+	{"events", "list", "", 0, "list the events owned by a user", listEvents},
+	{"events", "get", "EVENT_ID", 1, "show an event", getEvent},
+	{"events", "create", "", 0, "create a draft event", createEvent},
+	{"events", "publish", "EVENT_ID", 1, "publish an event", eventAction((*eventbrite.Client).EventPublish)},
+	{"events", "unpublish", "EVENT_ID", 1, "unpublish an event", eventAction((*eventbrite.Client).EventUnPublish)},
+	{"events", "cancel", "EVENT_ID", 1, "cancel an event", eventAction((*eventbrite.Client).EventCancel)},
+	{"events", "delete", "EVENT_ID", 1, "delete an event", eventAction((*eventbrite.Client).EventDelete)},
+
+	{"attendees", "list", "EVENT_ID", 1, "list the attendees of an event", listAttendees},
+	{"attendees", "get", "EVENT_ID ATTENDEE_ID", 2, "show an attendee", getAttendee},
+
+	{"orders", "list", "EVENT_ID", 1, "list the orders of an event", listOrders},
+	{"orders", "get", "ORDER_ID", 1, "show an order", getOrder},
+
+	{"webhooks", "list", "", 0, "list webhooks", listWebhooks},
+	{"webhooks", "create", "", 0, "create a webhook", createWebhook},
+	{"webhooks", "delete", "WEBHOOK_ID", 1, "delete a webhook", deleteWebhook},
+
+	{"venues", "get", "VENUE_ID", 1, "show a venue", getVenue},
+	{"venues", "create", "", 0, "create a venue", createVenue},
+	{"venues", "events", "VENUE_ID", 1, "list the events of a venue", listVenueEvents},
+
+	{"organizers", "get", "ORGANIZER_ID", 1, "show an organizer", getOrganizer},
+	{"organizers", "events", "ORGANIZER_ID", 1, "list the events of an organizer", listOrganizerEvents},
+
+	{"discounts", "get", "DISCOUNT_ID", 1, "show a discount", getDiscount},
+	{"discounts", "create", "", 0, "create a discount", createDiscount},
+	{"discounts", "delete", "DISCOUNT_ID", 1, "delete a discount", deleteDiscount},
+}
+
+func findCommand(resource, name string) *command {
+	for i := range commands {
+		if commands[i].resource == resource && commands[i].name == name {
+			return &commands[i]
+		}
+	}
+	return nil
+}
+
+// collect reads up to limit items from the iterator, all of them when limit is not positive
+func collect[T any](it *eventbrite.Iterator[T], limit int) ([]T, error) {
+	items := []T{}
+	for (limit <= 0 || len(items) < limit) && it.Next() {
+		items = append(items, it.Item())
+	}
+	return items, it.Err()
+}
+
+// parseTime parses an RFC 3339 time given on the command line
+func parseTime(name, value string) (eventbrite.DateTime, error) {
+	t, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return eventbrite.DateTime{}, fmt.Errorf("-%s: want an RFC 3339 time like 2006-01-02T15:04:05Z", name)
+	}
+	return eventbrite.DateTime{Time: t.UTC()}, nil
+}
+
+func formatFloat(f float64) string {
+	if f == 0 {
+		return ""
+	}
+	return strconv.FormatFloat(f, 'f', -1, 64)
+}
@@ -0,0 +1,63 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"strconv"
+
+	"github.com/apzuk3/go-eventbrite"
+
+	"golang.org/x/net/context"
+)
+
+var discountColumns = []column[eventbrite.CrossEventDiscount]{
+	{"ID", func(d eventbrite.CrossEventDiscount) string { return d.ID }},
+	{"CODE", func(d eventbrite.CrossEventDiscount) string { return d.Code }},
+	{"TYPE", func(d eventbrite.CrossEventDiscount) string { return d.Type }},
+	{"AMOUNT_OFF", func(d eventbrite.CrossEventDiscount) string { return formatFloat(d.AmountOff) }},
+	{"PERCENT_OFF", func(d eventbrite.CrossEventDiscount) string { return formatFloat(d.PercentOff) }},
+	{"AVAILABLE", func(d eventbrite.CrossEventDiscount) string { return strconv.Itoa(d.QuantityAvailable) }},
+	{"SOLD", func(d eventbrite.CrossEventDiscount) string { return strconv.Itoa(d.QuantitySold) }},
+}
+
+func getDiscount(fs *flag.FlagSet) runFunc {
+	return func(ctx context.Context, e *env, args []string) error {
+		discount, err := e.client.DiscountsGet(ctx, args[0])
+		if err != nil {
+			return err
+		}
+		return printOne(e.out, *discount, discountColumns)
+	}
+}
+
+func createDiscount(fs *flag.FlagSet) runFunc {
+	req := &eventbrite.DiscountCreateRequest{}
+	fs.StringVar(&req.Code, "code", "", "code activating the discount")
+	fs.StringVar(&req.Type, "type", "coded", "access, coded, public or hold")
+	fs.Float64Var(&req.AmountOff, "amount-off", 0, "fixed reduction amount")
+	fs.Float64Var(&req.PercentOff, "percent-off", 0, "percentage reduction, from 1.00 to 100.00")
+	fs.IntVar(&req.QuantityAvailable, "quantity", 0, "number of uses, 0 for unlimited")
+	fs.StringVar(&req.EventID, "event", "", "ID of the event of a single event discount")
+
+	return func(ctx context.Context, e *env, _ []string) error {
+		if req.Code == "" {
+			return errors.New("-code is required")
+		}
+
+		discount, err := e.client.DiscountCreate(ctx, req)
+		if err != nil {
+			return err
+		}
+		return printOne(e.out, *discount, discountColumns)
+	}
+}
+
+func deleteDiscount(fs *flag.FlagSet) runFunc {
+	return func(ctx context.Context, e *env, args []string) error {
+		res, err := e.client.DiscountDelete(ctx, args[0])
+		if err != nil {
+			return err
+		}
+		return e.out.printValue(res)
+	}
+}
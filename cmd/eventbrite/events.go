@@ -0,0 +1,126 @@
+package main
+
+import (
+	"errors"
+	"flag"
+
+	"github.com/apzuk3/go-eventbrite"
+
+	"golang.org/x/net/context"
+)
+
+var eventColumns = []column[eventbrite.Event]{
+	{"ID", func(e eventbrite.Event) string { return e.Id }},
+	{"NAME", func(e eventbrite.Event) string { return e.Name.Text }},
+	{"START", func(e eventbrite.Event) string { return e.Start.Local }},
+	{"TIMEZONE", func(e eventbrite.Event) string { return e.Start.Timezone }},
+	{"STATUS", func(e eventbrite.Event) string { return e.Status }},
+	{"URL", func(e eventbrite.Event) string { return e.Url }},
+}
+
+func searchEvents(fs *flag.FlagSet) runFunc {
+	req := &eventbrite.EventSearchRequest{}
+	fs.StringVar(&req.Query, "q", "", "keywords")
+	fs.StringVar(&req.LocationAddress, "address", "", "address of the location to search around")
+	fs.StringVar(&req.LocationWithin, "within", "", "distance around the address, like 10km")
+	fs.StringVar(&req.OrganizerId, "organizer", "", "only events of this organizer")
+	fs.StringVar(&req.Categories, "categories", "", "comma separated category IDs")
+	limit := fs.Int("limit", 50, "maximum number of events, 0 for all")
+
+	return func(ctx context.Context, e *env, _ []string) error {
+		events, err := collect(e.client.EventSearchIterator(ctx, req), *limit)
+		if err != nil {
+			return err
+		}
+		return printList(e.out, events, eventColumns)
+	}
+}
+
+func listEvents(fs *flag.FlagSet) runFunc {
+	req := &eventbrite.UserOwnedEventsRequest{}
+	user := fs.String("user", "me", "ID of the user owning the events")
+	fs.StringVar(&req.Status, "status", "", "comma separated statuses: all, draft, live, canceled, started or ended")
+	fs.StringVar(&req.OrderBy, "order", "", "start_asc, start_desc, created_asc, created_desc, name_asc or name_desc")
+	limit := fs.Int("limit", 0, "maximum number of events, 0 for all")
+
+	return func(ctx context.Context, e *env, _ []string) error {
+		events, err := collect(e.client.UserOwnedEventsIterator(ctx, *user, req), *limit)
+		if err != nil {
+			return err
+		}
+		return printList(e.out, events, eventColumns)
+	}
+}
+
+func getEvent(fs *flag.FlagSet) runFunc {
+	return func(ctx context.Context, e *env, args []string) error {
+		event, err := e.client.EventGet(ctx, args[0])
+		if err != nil {
+			return err
+		}
+		return printOne(e.out, *event, eventColumns)
+	}
+}
+
+func createEvent(fs *flag.FlagSet) runFunc {
+	name := fs.String("name", "", "name of the event")
+	description := fs.String("description", "", "HTML description of the event")
+	start := fs.String("start", "", "start time, RFC 3339")
+	end := fs.String("end", "", "end time, RFC 3339")
+	timezone := fs.String("timezone", "UTC", "timezone of the event, Olson format")
+	currency := fs.String("currency", "USD", "currency of the event")
+	organizer := fs.String("organizer", "", "ID of the organizer")
+	venue := fs.String("venue", "", "ID of the venue")
+	capacity := fs.Int("capacity", 0, "capacity of the event, defaults to the sum of the ticket capacities")
+	online := fs.Bool("online", false, "the event is online only")
+	listed := fs.Bool("listed", true, "the event is publicly listed")
+
+	return func(ctx context.Context, e *env, _ []string) error {
+		if *name == "" || *start == "" || *end == "" {
+			return errors.New("-name, -start and -end are required")
+		}
+
+		startUtc, err := parseTime("start", *start)
+		if err != nil {
+			return err
+		}
+		endUtc, err := parseTime("end", *end)
+		if err != nil {
+			return err
+		}
+
+		req := &eventbrite.EventCreateRequest{Event: eventbrite.EventFields{
+			Name:        &eventbrite.HTMLText{HTML: *name},
+			Start:       &eventbrite.EventTime{Utc: startUtc, Timezone: *timezone},
+			End:         &eventbrite.EventTime{Utc: endUtc, Timezone: *timezone},
+			Currency:    *currency,
+			OrganizerID: *organizer,
+			VenueID:     *venue,
+			Capacity:    *capacity,
+			OnlineEvent: eventbrite.Bool(*online),
+			Listed:      eventbrite.Bool(*listed),
+		}}
+		if *description != "" {
+			req.Event.Description = &eventbrite.HTMLText{HTML: *description}
+		}
+
+		event, err := e.client.EventCreate(ctx, req)
+		if err != nil {
+			return err
+		}
+		return printOne(e.out, *event, eventColumns)
+	}
+}
+
+// eventAction runs an action on an event, like publishing or canceling it
+func eventAction(action func(*eventbrite.Client, context.Context, string) (interface{}, error)) func(fs *flag.FlagSet) runFunc {
+	return func(fs *flag.FlagSet) runFunc {
+		return func(ctx context.Context, e *env, args []string) error {
+			res, err := action(e.client, ctx, args[0])
+			if err != nil {
+				return err
+			}
+			return e.out.printValue(res)
+		}
+	}
+}
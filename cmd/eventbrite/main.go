@@ -0,0 +1,188 @@
+// Command eventbrite runs everyday Eventbrite operations from the command line.
+//
+// Usage:
+//
+//	eventbrite [flags] <resource> <command> [command flags] [arguments]
+//
+// For example:
+//
+//	eventbrite events list -status live
+//	eventbrite -o csv attendees list 123456789 > attendees.csv
+//	eventbrite webhooks create -url https://example.com/hook -actions order.placed
+//
+// The token is taken from the -token flag, the EVENTBRITE_TOKEN environment variable or the
+// token key of the config file, in that order. The config file is a JSON object, by default
+// read from eventbrite/config.json under the user config directory:
+//
+//	{"token": "MY_TOKEN"}
+//
+// Run eventbrite -h for the list of commands.
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/apzuk3/go-eventbrite"
+
+	"golang.org/x/net/context"
+)
+
+// config is the content of the config file
+type config struct {
+	// The token used when neither the flag nor the environment variable are set
+	Token string `json:"token"`
+	// The API base url, only useful against a fake server
+	BaseURL string `json:"base_url"`
+}
+
+func main() {
+	os.Exit(run(context.Background(), os.Args[1:], os.Stdout, os.Stderr))
+}
+
+// run executes the command line and returns the exit status
+func run(ctx context.Context, args []string, stdout, stderr io.Writer) int {
+	fs := flag.NewFlagSet("eventbrite", flag.ContinueOnError)
+	fs.SetOutput(stderr)
+	token := fs.String("token", "", "API token, defaults to $EVENTBRITE_TOKEN")
+	configPath := fs.String("config", defaultConfigPath(), "config file")
+	format := fs.String("o", formatTable, "output format: json, table or csv")
+	fs.Usage = func() { usage(fs, stderr) }
+
+	if err := fs.Parse(args); err != nil {
+		return 2
+	}
+	if fs.NArg() < 2 {
+		fs.Usage()
+		return 2
+	}
+
+	cmd := findCommand(fs.Arg(0), fs.Arg(1))
+	if cmd == nil {
+		fmt.Fprintf(stderr, "eventbrite: unknown command %q\n", fs.Arg(0)+" "+fs.Arg(1))
+		return 2
+	}
+
+	p, err := newPrinter(*format, stdout)
+	if err != nil {
+		fmt.Fprintln(stderr, "eventbrite:", err)
+		return 2
+	}
+
+	cmdFlags := flag.NewFlagSet(cmd.resource+" "+cmd.name, flag.ContinueOnError)
+	cmdFlags.SetOutput(stderr)
+	cmdFlags.Usage = func() {
+		fmt.Fprintf(stderr, "Usage: eventbrite %s %s [flags] %s\n\n%s\n", cmd.resource, cmd.name, cmd.args, cmd.help)
+		cmdFlags.PrintDefaults()
+	}
+	runCmd := cmd.setup(cmdFlags)
+	if err := cmdFlags.Parse(fs.Args()[2:]); err != nil {
+		return 2
+	}
+	if cmdFlags.NArg() != cmd.nargs {
+		cmdFlags.Usage()
+		return 2
+	}
+
+	conf, err := loadConfig(*configPath)
+	if err != nil {
+		fmt.Fprintln(stderr, "eventbrite:", err)
+		return 1
+	}
+
+	client, err := newClient(firstOf(*token, os.Getenv("EVENTBRITE_TOKEN"), conf.Token), firstOf(os.Getenv("EVENTBRITE_BASE_URL"), conf.BaseURL))
+	if err != nil {
+		fmt.Fprintln(stderr, "eventbrite:", err)
+		return 1
+	}
+
+	if err := runCmd(ctx, &env{client: client, out: p}, cmdFlags.Args()); err != nil {
+		printError(stderr, err)
+		return 1
+	}
+	return 0
+}
+
+func newClient(token, baseURL string) (*eventbrite.Client, error) {
+	if token == "" {
+		return nil, errors.New("no token, set -token, $EVENTBRITE_TOKEN or the token of the config file")
+	}
+
+	options := []eventbrite.ClientOption{
+		eventbrite.WithToken(token),
+		eventbrite.WithRetryPolicy(eventbrite.DefaultRetryPolicy),
+	}
+	if baseURL != "" {
+		options = append(options, eventbrite.WithBaseURL(strings.TrimSuffix(baseURL, "/")))
+	}
+	return eventbrite.NewClient(options...)
+}
+
+func defaultConfigPath() string {
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return ""
+	}
+	return filepath.Join(dir, "eventbrite", "config.json")
+}
+
+// loadConfig reads the config file, a missing file is an empty config
+func loadConfig(path string) (*config, error) {
+	conf := &config{}
+	if path == "" {
+		return conf, nil
+	}
+
+	b, err := ioutil.ReadFile(path)
+	if os.IsNotExist(err) {
+		return conf, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(b, conf); err != nil {
+		return nil, fmt.Errorf("%s: %v", path, err)
+	}
+	return conf, nil
+}
+
+func firstOf(values ...string) string {
+	for _, v := range values {
+		if v != "" {
+			return v
+		}
+	}
+	return ""
+}
+
+// printError prints the error along with the invalid arguments of an ARGUMENTS_ERROR
+func printError(w io.Writer, err error) {
+	fmt.Fprintln(w, "eventbrite:", err)
+
+	var apiErr *eventbrite.Error
+	if !errors.As(err, &apiErr) {
+		return
+	}
+
+	for _, f := range apiErr.Fields {
+		fmt.Fprintf(w, "  %s: %s\n", f.Argument, strings.Join(f.Codes, ", "))
+	}
+}
+
+func usage(fs *flag.FlagSet, w io.Writer) {
+	fmt.Fprintf(w, "Usage: eventbrite [flags] <resource> <command> [command flags] [arguments]\n\nFlags:\n")
+	fs.PrintDefaults()
+
+	fmt.Fprintf(w, "\nCommands:\n")
+	for _, cmd := range commands {
+		fmt.Fprintf(w, "  %-40s %s\n", cmd.resource+" "+cmd.name+" "+cmd.args, cmd.help)
+	}
+	fmt.Fprintf(w, "\nRun eventbrite <resource> <command> -h for the flags of a command.\n")
+}
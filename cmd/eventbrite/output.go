@@ -0,0 +1,120 @@
+package main
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"text/tabwriter"
+)
+
+// Output formats
+const (
+	formatJSON  = "json"
+	formatTable = "table"
+	formatCSV   = "csv"
+)
+
+// printer writes command results in the output format selected with -o
+type printer struct {
+	format string
+	w      io.Writer
+}
+
+func newPrinter(format string, w io.Writer) (*printer, error) {
+	switch format {
+	case formatJSON, formatTable, formatCSV:
+		return &printer{format: format, w: w}, nil
+	}
+	return nil, fmt.Errorf("unknown output format %q, want json, table or csv", format)
+}
+
+// column is a column of the table and csv outputs
+type column[T any] struct {
+	name  string
+	value func(T) string
+}
+
+// printList writes a list of objects, as a JSON array or as one row per object
+func printList[T any](p *printer, items []T, cols []column[T]) error {
+	if p.format == formatJSON {
+		return p.json(items)
+	}
+
+	rows := make([][]string, 0, len(items))
+	for _, item := range items {
+		row := make([]string, len(cols))
+		for i, col := range cols {
+			row[i] = col.value(item)
+		}
+		rows = append(rows, row)
+	}
+
+	header := make([]string, len(cols))
+	for i, col := range cols {
+		header[i] = col.name
+	}
+	return p.rows(header, rows)
+}
+
+// printOne writes a single object, as a JSON object or as a single row
+func printOne[T any](p *printer, item T, cols []column[T]) error {
+	if p.format == formatJSON {
+		return p.json(item)
+	}
+	return printList(p, []T{item}, cols)
+}
+
+// printValue writes an untyped response, objects are written as one key/value row per field
+func (p *printer) printValue(v interface{}) error {
+	if p.format == formatJSON {
+		return p.json(v)
+	}
+
+	m, ok := v.(map[string]interface{})
+	if !ok {
+		return p.rows([]string{"VALUE"}, [][]string{{fmt.Sprint(v)}})
+	}
+
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	rows := make([][]string, 0, len(keys))
+	for _, k := range keys {
+		rows = append(rows, []string{k, fmt.Sprint(m[k])})
+	}
+	return p.rows([]string{"KEY", "VALUE"}, rows)
+}
+
+func (p *printer) json(v interface{}) error {
+	enc := json.NewEncoder(p.w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(v)
+}
+
+func (p *printer) rows(header []string, rows [][]string) error {
+	if p.format == formatCSV {
+		w := csv.NewWriter(p.w)
+		w.Write(header)
+		w.WriteAll(rows)
+		return w.Error()
+	}
+
+	// cells must not break the alignment of the table
+	clean := strings.NewReplacer("\t", " ", "\n", " ", "\r", "")
+
+	w := tabwriter.NewWriter(p.w, 0, 4, 2, ' ', 0)
+	fmt.Fprintln(w, strings.Join(header, "\t"))
+	for _, row := range rows {
+		for i := range row {
+			row[i] = clean.Replace(row[i])
+		}
+		fmt.Fprintln(w, strings.Join(row, "\t"))
+	}
+	return w.Flush()
+}
@@ -0,0 +1,97 @@
+package main
+
+import (
+	"errors"
+	"flag"
+
+	"github.com/apzuk3/go-eventbrite"
+
+	"golang.org/x/net/context"
+)
+
+var venueColumns = []column[eventbrite.Venue]{
+	{"ID", func(v eventbrite.Venue) string { return v.ID }},
+	{"NAME", func(v eventbrite.Venue) string { return v.Name }},
+	{"ADDRESS", func(v eventbrite.Venue) string { return v.Address.LocalizedAddressDisplay }},
+	{"CITY", func(v eventbrite.Venue) string { return v.Address.City }},
+	{"COUNTRY", func(v eventbrite.Venue) string { return v.Address.Country }},
+}
+
+var organizerColumns = []column[eventbrite.Organizer]{
+	{"ID", func(o eventbrite.Organizer) string { return o.ID }},
+	{"NAME", func(o eventbrite.Organizer) string { return o.Name }},
+	{"URL", func(o eventbrite.Organizer) string { return o.Url }},
+}
+
+func getVenue(fs *flag.FlagSet) runFunc {
+	return func(ctx context.Context, e *env, args []string) error {
+		venue, err := e.client.VenueGet(ctx, args[0])
+		if err != nil {
+			return err
+		}
+		return printOne(e.out, *venue, venueColumns)
+	}
+}
+
+func createVenue(fs *flag.FlagSet) runFunc {
+	req := &eventbrite.CreateVenueRequest{}
+	fs.StringVar(&req.Name, "name", "", "name of the venue")
+	fs.StringVar(&req.OrganizerID, "organizer", "", "ID of the organizer owning the venue")
+	fs.StringVar(&req.Address1, "address", "", "first line of the address")
+	fs.StringVar(&req.Address2, "address2", "", "second line of the address")
+	fs.StringVar(&req.City, "city", "", "city")
+	fs.StringVar(&req.Region, "region", "", "region")
+	fs.StringVar(&req.PostalCode, "postal-code", "", "postal code")
+	fs.StringVar(&req.Country, "country", "", "ISO 3166 country code")
+	fs.IntVar(&req.Capacity, "capacity", 0, "maximum capacity")
+
+	return func(ctx context.Context, e *env, _ []string) error {
+		if req.Name == "" {
+			return errors.New("-name is required")
+		}
+
+		venue, err := e.client.VenueCreate(ctx, req)
+		if err != nil {
+			return err
+		}
+		return printOne(e.out, *venue, venueColumns)
+	}
+}
+
+func listVenueEvents(fs *flag.FlagSet) runFunc {
+	req := &eventbrite.GetVenueEventsRequest{}
+	fs.StringVar(&req.Status, "status", "", "comma separated statuses: all, draft, live, canceled, started or ended")
+	limit := fs.Int("limit", 0, "maximum number of events, 0 for all")
+
+	return func(ctx context.Context, e *env, args []string) error {
+		events, err := collect(e.client.VenueEventsIterator(ctx, args[0], req), *limit)
+		if err != nil {
+			return err
+		}
+		return printList(e.out, events, eventColumns)
+	}
+}
+
+func getOrganizer(fs *flag.FlagSet) runFunc {
+	return func(ctx context.Context, e *env, args []string) error {
+		organizer, err := e.client.OrganizerGet(ctx, args[0])
+		if err != nil {
+			return err
+		}
+		return printOne(e.out, *organizer, organizerColumns)
+	}
+}
+
+func listOrganizerEvents(fs *flag.FlagSet) runFunc {
+	req := &eventbrite.OrganizerEventsRequest{}
+	fs.StringVar(&req.Status, "status", "", "comma separated statuses: all, draft, live, canceled, started or ended")
+	limit := fs.Int("limit", 0, "maximum number of events, 0 for all")
+
+	return func(ctx context.Context, e *env, args []string) error {
+		events, err := collect(e.client.OrganizerEventsIterator(ctx, args[0], req), *limit)
+		if err != nil {
+			return err
+		}
+		return printList(e.out, events, eventColumns)
+	}
+}
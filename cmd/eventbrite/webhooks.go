@@ -0,0 +1,59 @@
+package main
+
+import (
+	"errors"
+	"flag"
+
+	"github.com/apzuk3/go-eventbrite"
+
+	"golang.org/x/net/context"
+)
+
+var webhookColumns = []column[eventbrite.Webhook]{
+	{"ID", func(wh eventbrite.Webhook) string { return wh.ID }},
+	{"ENDPOINT_URL", func(wh eventbrite.Webhook) string { return wh.EndpointUrl }},
+	{"ACTIONS", func(wh eventbrite.Webhook) string { return wh.Actions }},
+	{"EVENT_ID", func(wh eventbrite.Webhook) string { return wh.EventID }},
+}
+
+func listWebhooks(fs *flag.FlagSet) runFunc {
+	req := &eventbrite.WebhooksRequest{}
+	fs.StringVar(&req.OrganizationID, "org", "", "ID of the organization owning the webhooks")
+
+	return func(ctx context.Context, e *env, _ []string) error {
+		webhooks, err := collect(e.client.WebhooksIterator(ctx, req), 0)
+		if err != nil {
+			return err
+		}
+		return printList(e.out, webhooks, webhookColumns)
+	}
+}
+
+func createWebhook(fs *flag.FlagSet) runFunc {
+	req := &eventbrite.CreateWebhookRequest{}
+	fs.StringVar(&req.EndpointUrl, "url", "", "url the deliveries are sent to")
+	fs.StringVar(&req.Actions, "actions", "", "comma separated actions, like order.placed,event.published")
+	fs.StringVar(&req.OrganizationID, "org", "", "ID of the organization owning the webhook")
+	fs.StringVar(&req.EventID, "event", "", "only trigger for this event")
+
+	return func(ctx context.Context, e *env, _ []string) error {
+		if req.EndpointUrl == "" {
+			return errors.New("-url is required")
+		}
+
+		wh, err := e.client.WebhookCreate(ctx, req)
+		if err != nil {
+			return err
+		}
+		return printOne(e.out, *wh, webhookColumns)
+	}
+}
+
+func deleteWebhook(fs *flag.FlagSet) runFunc {
+	return func(ctx context.Context, e *env, args []string) error {
+		if _, err := e.client.WebhookDelete(ctx, args[0]); err != nil {
+			return err
+		}
+		return e.out.printValue(map[string]interface{}{"id": args[0], "deleted": true})
+	}
+}
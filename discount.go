@@ -18,6 +18,8 @@ import (
 //
 // https://www.eventbrite.co.uk/developer/v3/response_formats/event/#ebapi-std:format-cross_event_discount
 type CrossEventDiscount struct {
+	// The discount ID
+	ID string `json:"id"`
 	// The name of the discount (on public discounts) or the code that
 	// user should provide in order to activate it (on access codes or coded discounts)
 	Code string `json:"code"`
@@ -127,7 +129,7 @@ type DiscountUpdateRequest struct {
 func (c *Client) DiscountsGet(ctx context.Context, id string) (*CrossEventDiscount, error) {
 	d := new(CrossEventDiscount)
 
-	return d, c.getJSON(ctx, fmt.Sprintf("/discounts/%s/", id), nil, d)
+	return d, c.getJSON(ctx, "DiscountsGet", fmt.Sprintf("/discounts/%s/", id), nil, d)
 }
 
 // DiscountCreate creates a discount. Returns the created cross_event_discount.
@@ -156,7 +158,7 @@ func (c *Client) DiscountsGet(ctx context.Context, id string) (*CrossEventDiscou
 func (c *Client) DiscountCreate(ctx context.Context, req *DiscountCreateRequest) (*CrossEventDiscount, error) {
 	d := new(CrossEventDiscount)
 
-	return d, c.postJSON(ctx, "/discounts/", req, d)
+	return d, c.postJSON(ctx, "DiscountCreate", "/discounts/", req, d)
 }
 
 // DiscountUpdate updates the discount with the specified :discount_id. Returns the updated cross_event_discount.
@@ -167,7 +169,7 @@ func (c *Client) DiscountCreate(ctx context.Context, req *DiscountCreateRequest)
 func (c *Client) DiscountUpdate(ctx context.Context, id string, req *DiscountUpdateRequest) (*CrossEventDiscount, error) {
 	d := new(CrossEventDiscount)
 
-	return d, c.postJSON(ctx, fmt.Sprintf("/discounts/%s/", id), req, d)
+	return d, c.postJSON(ctx, "DiscountUpdate", fmt.Sprintf("/discounts/%s/", id), req, d)
 }
 
 // DiscountDelete deletes the cross_event_discount with the specified :discount_id. Only unused discounts can be deleted.
@@ -177,5 +179,5 @@ func (c *Client) DiscountUpdate(ctx context.Context, id string, req *DiscountUpd
 func (c *Client) DiscountDelete(ctx context.Context, id string) (interface{}, error) {
 	var v interface{}
 
-	return v, c.deleteJSON(ctx, fmt.Sprintf("/discounts/%s/", id), &v)
+	return v, c.deleteJSON(ctx, "DiscountDelete", fmt.Sprintf("/discounts/%s/", id), &v)
 }
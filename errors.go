@@ -0,0 +1,270 @@
+package eventbrite
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"reflect"
+	"sort"
+	"strings"
+)
+
+// Sentinel errors matching the documented error keys of the API. They are meant to be used
+// with errors.Is, the error returned by the client is an *Error carrying the full details
+//
+//	if errors.Is(err, eventbrite.ErrNotFound) {
+//		// handle me
+//	}
+//
+// https://www.eventbrite.com/developer/v3/api_overview/errors/#ebapi-common-errors
+var (
+	// The request is missing its token
+	ErrNoAuth = &Error{Err: "NO_AUTH"}
+	// The token is invalid
+	ErrInvalidAuth = &Error{Err: "INVALID_AUTH"}
+	// The token is valid but not allowed to access the object
+	ErrNotAuthorized = &Error{Err: "NOT_AUTHORIZED"}
+	// Some arguments are missing or invalid, see Error.Arguments
+	ErrArguments = &Error{Err: "ARGUMENTS_ERROR"}
+	// The page number is out of range
+	ErrBadPage = &Error{Err: "BAD_PAGE"}
+	// An expansion could not be performed
+	ErrExpansionFailed = &Error{Err: "EXPANSION_FAILED"}
+	// The token exceeded its rate limit
+	ErrHitRateLimit = &Error{Err: "HIT_RATE_LIMIT"}
+	// The path does not exist
+	ErrNotFound = &Error{Err: "NOT_FOUND"}
+	// The HTTP method is not allowed on the path
+	ErrMethodNotAllowed = &Error{Err: "METHOD_NOT_ALLOWED"}
+	// An event has both a venue and the online flag set
+	ErrVenueAndOnline = &Error{Err: "VENUE_AND_ONLINE"}
+	// An error occurred on Eventbrite side
+	ErrInternal = &Error{Err: "INTERNAL_ERROR"}
+)
+
+// maxErrorBody bounds the size of a response body kept in an Error
+const maxErrorBody = 1 << 20
+
+// maxExcerpt bounds the excerpt of a non JSON body used as the description of an Error
+const maxExcerpt = 120
+
+// When an error occurs during an API request, you’ll get a response with an error HTTP status
+// (in the 400 or 500 range), as well as a JSON response containing more information about the error.
+//
+// https://www.eventbrite.co.uk/developer/v3/api_overview/errors/#ebapi-errors
+type Error struct {
+	// The error key contains a constant string value for error - in this case, VENUE_AND_ONLINE - and
+	// is what you should key your error handling off of, as this string won’t change depending on locale
+	// or as we change the API over time
+	Err string `json:"error"`
+	// The error_description key is for developer information only and will usually contain a more informative
+	// explanation for the error, should you be confused. You should not display this string to your users;
+	// it’s often very technical and may not be localized to their language
+	Description string `json:"error_description"`
+	// The status_code value just mirrors the HTTP status code you got as part of the request. It’s there as
+	// a convenience if your HTTP library makes it very hard to get status codes, or has one error handler
+	// for all error codes
+	Status int `json:"status_code"`
+	// The error codes of every invalid argument of an ARGUMENTS_ERROR, keyed by argument name
+	// (e.g. "event.start" => ["INVALID"])
+	Arguments map[string][]string `json:"-"`
+	// The invalid arguments mapped to the fields of the request they came from, set for the
+	// requests sending a body
+	Fields []FieldError `json:"-"`
+
+	// The HTTP method of the failed request
+	Method string `json:"-"`
+	// The API path of the failed request, without its query
+	Path string `json:"-"`
+	// The raw response body
+	Body []byte `json:"-"`
+	// The response headers
+	Header http.Header `json:"-"`
+}
+
+func (e *Error) Error() string {
+	key := e.Err
+	if key == "" {
+		key = http.StatusText(e.Status)
+	}
+
+	msg := fmt.Sprintf("Eventbrite API: [Status code - %d] %s", e.Status, key)
+	if e.Method != "" {
+		msg = fmt.Sprintf("Eventbrite API: %s %s: [Status code - %d] %s", e.Method, e.Path, e.Status, key)
+	}
+	if e.Description != "" {
+		msg += ": " + e.Description
+	}
+	return msg
+}
+
+// Is reports whether the error has the same key as target, so that errors.Is matches the
+// sentinel errors of the package. A target without key matches on the HTTP status.
+func (e *Error) Is(target error) bool {
+	t, ok := target.(*Error)
+	if !ok {
+		return false
+	}
+	if t.Err != "" {
+		return t.Err == e.Err
+	}
+	return t.Status != 0 && t.Status == e.Status
+}
+
+// Temporary reports whether the request may succeed if sent again later
+func (e *Error) Temporary() bool {
+	return e.Status == http.StatusTooManyRequests || e.Status >= 500
+}
+
+// UnmarshalJSON decodes the error along with the per argument errors found in error_detail
+func (e *Error) UnmarshalJSON(data []byte) error {
+	type plain Error
+	var v struct {
+		plain
+		Detail struct {
+			Arguments map[string]json.RawMessage `json:"ARGUMENTS_ERROR"`
+		} `json:"error_detail"`
+	}
+	if err := json.Unmarshal(data, &v); err != nil {
+		return err
+	}
+
+	*e = Error(v.plain)
+	if len(v.Detail.Arguments) > 0 {
+		e.Arguments = map[string][]string{}
+	}
+	for arg, raw := range v.Detail.Arguments {
+		e.Arguments[arg] = argumentCodes(raw)
+	}
+	return nil
+}
+
+// argumentCodes reads the error codes of an argument, given either as a list or a single value
+func argumentCodes(raw json.RawMessage) []string {
+	var codes []string
+	if err := json.Unmarshal(raw, &codes); err == nil {
+		return codes
+	}
+
+	var code string
+	if err := json.Unmarshal(raw, &code); err == nil {
+		return []string{code}
+	}
+	return []string{string(raw)}
+}
+
+// decodeResponse decodes a successful response into resp, or builds the *Error of a failed one.
+// Bodies which are not JSON, like the HTML pages of a failing proxy, still produce an *Error with
+// the HTTP status and the raw body.
+func decodeResponse(method, path string, httpResp *http.Response, resp interface{}) error {
+	if httpResp.StatusCode >= 200 && httpResp.StatusCode < 300 {
+		if resp == nil {
+			return nil
+		}
+		err := json.NewDecoder(httpResp.Body).Decode(resp)
+		if err == io.EOF {
+			return nil
+		}
+		return err
+	}
+
+	body, _ := ioutil.ReadAll(io.LimitReader(httpResp.Body, maxErrorBody))
+
+	respErr := &Error{}
+	if err := json.Unmarshal(body, respErr); err != nil || respErr.Err == "" {
+		respErr = &Error{Description: excerpt(body)}
+	}
+	respErr.Status = httpResp.StatusCode
+	respErr.Method = method
+	respErr.Path = path
+	respErr.Body = body
+	respErr.Header = httpResp.Header
+	return respErr
+}
+
+// excerpt returns the beginning of body on a single line, to describe an error answered
+// without a JSON body
+func excerpt(body []byte) string {
+	s := strings.Join(strings.Fields(string(body)), " ")
+	if r := []rune(s); len(r) > maxExcerpt {
+		s = string(r[:maxExcerpt]) + "..."
+	}
+	return s
+}
+
+// FieldError is an invalid argument of an ARGUMENTS_ERROR along with the request field it
+// was read from
+type FieldError struct {
+	// The argument name, like event.start.utc
+	Argument string
+	// The path of the request field, like Event.Start.Utc. Empty when no field matches the argument
+	Field string
+	// The error codes, like MISSING or INVALID
+	Codes []string
+}
+
+func (e FieldError) Error() string {
+	name := e.Field
+	if name == "" {
+		name = e.Argument
+	}
+	return name + ": " + strings.Join(e.Codes, ", ")
+}
+
+// fieldErrors maps the arguments of an ARGUMENTS_ERROR to the fields of req, following the
+// json names of its fields. Dotted names like "event.name.html" match either nested structs
+// or a single field tagged with the whole name.
+func fieldErrors(args map[string][]string, req interface{}) []FieldError {
+	if len(args) == 0 {
+		return nil
+	}
+
+	var typ reflect.Type
+	if req != nil {
+		typ = reflect.TypeOf(req)
+	}
+
+	res := make([]FieldError, 0, len(args))
+	for arg, codes := range args {
+		res = append(res, FieldError{Argument: arg, Field: fieldPath(typ, arg), Codes: codes})
+	}
+	sort.Slice(res, func(i, j int) bool { return res[i].Argument < res[j].Argument })
+	return res
+}
+
+// fieldPath returns the path of the field of typ whose json name is arg
+func fieldPath(typ reflect.Type, arg string) string {
+	for typ != nil && typ.Kind() == reflect.Ptr {
+		typ = typ.Elem()
+	}
+	if typ == nil || typ.Kind() != reflect.Struct {
+		return ""
+	}
+
+	for i := 0; i < typ.NumField(); i++ {
+		f := typ.Field(i)
+		name := strings.Split(f.Tag.Get("json"), ",")[0]
+
+		if f.Anonymous && name == "" {
+			if path := fieldPath(f.Type, arg); path != "" {
+				return path
+			}
+			continue
+		}
+		if name == "" {
+			name = f.Name
+		}
+
+		switch {
+		case name == arg:
+			return f.Name
+		case strings.HasPrefix(arg, name+"."):
+			if path := fieldPath(f.Type, strings.TrimPrefix(arg, name+".")); path != "" {
+				return f.Name + "." + path
+			}
+		}
+	}
+	return ""
+}
@@ -0,0 +1,67 @@
+package eventbrite
+
+import (
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func TestDecodeResponseError(t *testing.T) {
+	tests := []struct {
+		name     string
+		status   int
+		body     string
+		wantKey  string
+		wantDesc string
+		wantMsg  string
+	}{
+		{
+			name:     "json",
+			status:   http.StatusBadRequest,
+			body:     `{"error":"VENUE_AND_ONLINE","error_description":"You cannot both specify a venue and set online_event","status_code":400}`,
+			wantKey:  "VENUE_AND_ONLINE",
+			wantDesc: "You cannot both specify a venue and set online_event",
+			wantMsg:  "Eventbrite API: GET /events/1/: [Status code - 400] VENUE_AND_ONLINE: You cannot both specify a venue and set online_event",
+		},
+		{
+			name:    "empty body",
+			status:  http.StatusNotFound,
+			wantMsg: "Eventbrite API: GET /events/1/: [Status code - 404] Not Found",
+		},
+		{
+			name:     "html body",
+			status:   http.StatusBadGateway,
+			body:     "<html>\n  <body>Bad gateway</body>\n</html>\n",
+			wantDesc: "<html> <body>Bad gateway</body> </html>",
+			wantMsg:  "Eventbrite API: GET /events/1/: [Status code - 502] Bad Gateway: <html> <body>Bad gateway</body> </html>",
+		},
+		{
+			name:     "long body",
+			status:   http.StatusServiceUnavailable,
+			body:     strings.Repeat("x", 500),
+			wantDesc: strings.Repeat("x", maxExcerpt) + "...",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			httpResp := &http.Response{StatusCode: tt.status, Body: ioutil.NopCloser(strings.NewReader(tt.body))}
+			err := decodeResponse(http.MethodGet, "/events/1/", httpResp, nil)
+
+			apiErr, ok := err.(*Error)
+			if !ok {
+				t.Fatalf("decodeResponse() = %#v, want an *Error", err)
+			}
+			if apiErr.Err != tt.wantKey || apiErr.Description != tt.wantDesc || apiErr.Status != tt.status {
+				t.Errorf("error = {%q %q %d}, want {%q %q %d}", apiErr.Err, apiErr.Description, apiErr.Status, tt.wantKey, tt.wantDesc, tt.status)
+			}
+			if string(apiErr.Body) != tt.body {
+				t.Errorf("body = %q, want %q", apiErr.Body, tt.body)
+			}
+			if tt.wantMsg != "" && apiErr.Error() != tt.wantMsg {
+				t.Errorf("Error() = %q\nwant      %q", apiErr.Error(), tt.wantMsg)
+			}
+		})
+	}
+}
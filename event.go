@@ -2,7 +2,9 @@ package eventbrite
 
 import (
 	"fmt"
+	"net/http"
 	"net/url"
+	"strings"
 
 	"golang.org/x/net/context"
 )
@@ -58,12 +60,46 @@ type Event struct {
 	// The bookmark information on the event. Currently returns a dictionary with the number of users who
 	// have bookmarked the event as ‘count’ (i.e. {'count': 3})
 	BookmarkInfo interface{} `json:"bookmark_info"`
+	// The ticket classes of the event, only with the ticket_classes expansion
+	TicketClasses []TicketClass `json:"ticket_classes"`
+	// The availability of the tickets of the event, only with the ticket_availability expansion
+	TicketAvailability TicketAvailability `json:"ticket_availability"`
+	// Whether the event is part of a repeating event series
+	IsSeries bool `json:"is_series"`
+	// Whether the event is the parent of a repeating event series
+	IsSeriesParent bool `json:"is_series_parent"`
+	// The ID of the series parent, for the dates of a repeating event series
+	SeriesID string `json:"series_id"`
+	// The maximum number of attendees, the sum of the ticket class quantities unless custom
+	Capacity int `json:"capacity"`
+	// Whether the capacity has been set rather than derived from the ticket classes
+	CapacityIsCustom bool `json:"capacity_is_custom"`
+}
+
+// TicketAvailability summarizes the tickets still on sale for an event
+//
+// https://www.eventbrite.com/developer/v3/response_formats/event/#ebapi-ticket-availability
+type TicketAvailability struct {
+	// Whether there are any tickets left on sale
+	HasAvailableTickets bool `json:"has_available_tickets"`
+	// The price of the cheapest ticket on sale
+	MinimumTicketPrice Currency `json:"minimum_ticket_price"`
+	// The price of the most expensive ticket on sale
+	MaximumTicketPrice Currency `json:"maximum_ticket_price"`
+	// Whether the event is sold out
+	IsSoldOut bool `json:"is_sold_out"`
+	// When the first ticket sale starts
+	StartSalesDate DatetimeTz `json:"start_sales_date"`
+	// Whether a waitlist is available for the event
+	WaitlistAvailable bool `json:"waitlist_available"`
 }
 
 // EventSearchRequest is the request structure for searching Event
 //
 // https://www.eventbrite.com/developer/v3/endpoints/events/#ebapi-parameters
 type EventSearchRequest struct {
+	PageRequest
+
 	// Return events matching the given keywords. This parameter will accept any string as a keyword.
 	Query string `json:"q"`
 	// Parameter you want to sort by - options are “date”, “distance” and “best”. Prefix with a
@@ -127,114 +163,148 @@ type EventSearchRequest struct {
 	HighAffinityCategories string `json:"high_affinity_categories"`
 }
 
-// EventCreateRequest is the request structure for creating an Event
+// EventCreateRequest is the request structure for creating an Event. The name, start, end and
+// currency of the event are required.
 //
 // https://www.eventbrite.com/developer/v3/endpoints/events/#ebapi-id1
 type EventCreateRequest struct {
-	// The name of the event. Value cannot be empty nor whitespace.
-	NameHtml string `json:"event.name.html" validate:"required"`
-	// The ID of the organizer of this event
-	DescriptionHtml string `json:"event.description.html"`
-	// The ID of the organizer of this event
-	OrganizerID string `json:"event.organizer_id"`
-	// The start time of the event
-	StartUtc DateTime `json:"event.start.utc" validate:"required"`
-	// Yes Start time timezone (Olson format)
-	StartTimezone string `json:"event.start.timezone" validate:"required"`
-	// The end time of the event
-	EndUtc DateTime `json:"event.end.utc" validate:"required"`
-	// End time timezone (Olson format)
-	EndTimezone string `json:"event.end.timezone" validate:"required"`
-	// Whether the start date should be hidden
-	HideStartDate bool `json:"event.hide_start_date"`
-	// Whether the end date should be hidden
-	HideEndDate bool `json:"event.hide_end_date"`
-	// Event currency (3 letter code)
-	Currency string `json:"event.currency" validate:"required"`
-	// The ID of a previously-created venue to associate with this event. You can omit this field or
-	// set it to null if you set online_event.
-	VenueId string `json:"event.venue_id"`
-	// Is the event online-only (no venue)?
-	OnlineEvent bool `json:"event.online_event"`
-	// If the event is publicly listed and searchable. Defaults to True.
-	Listed bool `json:"event.listed"`
-	// The logo for the event
-	LogoID string `json:"event.logo_id"`
-	// The category (vertical) of the event
-	CategoryID string `json:"event.category_id"`
-	// The subcategory of the event (US only)
-	SubcategoryID string `json:"event.subcategory_id"`
-	// The format (general type) of the event
-	FormatID string `json:"event.format_id"`
-	// If users can share the event on social media
-	Sharable bool `json:"event.shareable"`
-	// Only invited users can see the event page
-	InviteOnly bool `json:"event.invite_only"`
-	// Password needed to see the event in unlisted mode
-	Password string `json:"event.password"`
-	// Set specific capacity (if omitted, sums ticket capacities)
-	Capacity int `json:"event.capacity"`
-	// If the remaining number of tickets is publicly visible on the event page
-	ShowRemaining bool `json:"event.show_remaining"`
-	// If the event is reserved seating
-	IsReservedSeating bool `json:"event.is_reserved_seating"`
-	// Source of the event (defaults to API)
-	Source string `json:"event.source"`
+	Event EventFields `json:"event"`
 }
 
-// EventUpdateRequest is the request structure for updating an Event
+// EventUpdateRequest is the request structure for updating an Event. Only the fields which are
+// set are changed.
 //
 // https://www.eventbrite.com/developer/v3/endpoints/events/#ebapi-id5
 type EventUpdateRequest struct {
+	Event EventFields `json:"event"`
+}
+
+// EventFields holds the writable fields of an Event. Fields left to their zero value are not
+// sent, booleans are pointers so that false can be sent explicitly, see Bool.
+type EventFields struct {
 	// The name of the event. Value cannot be empty nor whitespace.
-	NameHtml string `json:"event.name.html"`
+	Name *HTMLText `json:"name,omitempty"`
+	// The description on the event page
+	Description *HTMLText `json:"description,omitempty"`
 	// The ID of the organizer of this event
-	DescriptionHtml string `json:"event.description.html" validate:"required"`
-	// The ID of the organizer of this event
-	OrganizerId string `json:"event.organizer_id" validate:"required"`
+	OrganizerID string `json:"organizer_id,omitempty"`
 	// The start time of the event
-	StartUtc string `json:"event.start.utc" validate:"required"`
-	// Start time timezone (Olson format)
-	StartTimezone string `json:"event.start.timezone" validate:"required"`
+	Start *EventTime `json:"start,omitempty"`
 	// The end time of the event
-	EndUtc string `json:"event.end.utc" validate:"required"`
-	// End time timezone (Olson format)
-	EndTimezone string `json:"event.end.timezone" validate:"required"`
+	End *EventTime `json:"end,omitempty"`
 	// Whether the start date should be hidden
-	HideStartDate bool `json:"event.hide_start_date"`
+	HideStartDate *bool `json:"hide_start_date,omitempty"`
 	// Whether the end date should be hidden
-	HideEndDate bool `json:"event.hide_end_date"`
+	HideEndDate *bool `json:"hide_end_date,omitempty"`
 	// Event currency (3 letter code)
-	Currency string `json:"event.currency" validate:"required"`
-	// The ID of a previously-created venue to associate with this event. You can omit this field or
-	// set it to null if you set online_event.
-	VenueID string `json:"event.venue_id"`
+	Currency string `json:"currency,omitempty"`
+	// The ID of a previously-created venue to associate with this event. You can omit this field if
+	// you set online_event.
+	VenueID string `json:"venue_id,omitempty"`
 	// Is the event online-only (no venue)?
-	OnlineEvent bool `json:"event.online_event"`
+	OnlineEvent *bool `json:"online_event,omitempty"`
 	// If the event is publicly listed and searchable. Defaults to True.
-	Listed bool `json:"event.listed"`
+	Listed *bool `json:"listed,omitempty"`
 	// The logo for the event
-	LogoID string `json:"event.logo_id"`
+	LogoID string `json:"logo_id,omitempty"`
 	// The category (vertical) of the event
-	CategoryID string `json:"event.category_id"`
+	CategoryID string `json:"category_id,omitempty"`
 	// The subcategory of the event (US only)
-	SubcategoryID string `json:"event.subcategory_id"`
+	SubcategoryID string `json:"subcategory_id,omitempty"`
 	// The format (general type) of the event
-	FormatID string `json:"event.format_id"`
+	FormatID string `json:"format_id,omitempty"`
 	// If users can share the event on social media
-	Sharable bool `json:"event.shareable"`
+	Shareable *bool `json:"shareable,omitempty"`
 	// Only invited users can see the event page
-	InviteOnly bool `json:"event.invite_only"`
+	InviteOnly *bool `json:"invite_only,omitempty"`
 	// Password needed to see the event in unlisted mode
-	Password string `json:"event.password"`
+	Password string `json:"password,omitempty"`
 	// Set specific capacity (if omitted, sums ticket capacities)
-	Capacity int `json:"event.capacity"`
+	Capacity int `json:"capacity,omitempty"`
 	// If the remaining number of tickets is publicly visible on the event page
-	ShowRemaining bool `json:"event.show_remaining"`
+	ShowRemaining *bool `json:"show_remaining,omitempty"`
 	// If the event is reserved seating
-	IsReservedSeating bool `json:"event.is_reserved_seating"`
+	IsReservedSeating *bool `json:"is_reserved_seating,omitempty"`
 	// Source of the event (defaults to API)
-	Source string `json:"event.source"`
+	Source string `json:"source,omitempty"`
+	// The locale of the event page, like en_US
+	Locale string `json:"locale,omitempty"`
+}
+
+// HTMLText is a text written as HTML, Eventbrite derives its plain text version
+type HTMLText struct {
+	HTML string `json:"html"`
+}
+
+// EventTime is the start or end time of an event written by EventCreate and EventUpdate
+type EventTime struct {
+	// The time of the event, sent in UTC
+	Utc DateTime `json:"utc"`
+	// The timezone of the event (Olson format), like Europe/London
+	Timezone string `json:"timezone"`
+}
+
+// Bool returns a pointer to b, to set the optional booleans of a request
+func Bool(b bool) *bool {
+	return &b
+}
+
+// String returns a pointer to s, to set the optional strings of a request which may be cleared
+func String(s string) *string {
+	return &s
+}
+
+// validate reports the missing fields of the request the way the API does, as an ARGUMENTS_ERROR.
+// A nil request misses all of them.
+func (r *EventCreateRequest) validate() error {
+	if r == nil {
+		r = &EventCreateRequest{}
+	}
+	missing := map[string][]string{}
+	r.Event.addMissing("event.", missing)
+
+	return argumentsError(http.MethodPost, "/events/", missing, r)
+}
+
+// addMissing adds the arguments required to create an event which are not set, prefixed by the
+// name of the object holding the fields
+func (f *EventFields) addMissing(prefix string, missing map[string][]string) {
+	if f.Name == nil || strings.TrimSpace(f.Name.HTML) == "" {
+		missing[prefix+"name.html"] = []string{"MISSING"}
+	}
+	if f.Start == nil || f.Start.Utc.Time.IsZero() {
+		missing[prefix+"start.utc"] = []string{"MISSING"}
+	}
+	if f.Start == nil || f.Start.Timezone == "" {
+		missing[prefix+"start.timezone"] = []string{"MISSING"}
+	}
+	if f.End == nil || f.End.Utc.Time.IsZero() {
+		missing[prefix+"end.utc"] = []string{"MISSING"}
+	}
+	if f.End == nil || f.End.Timezone == "" {
+		missing[prefix+"end.timezone"] = []string{"MISSING"}
+	}
+	if f.Currency == "" {
+		missing[prefix+"currency"] = []string{"MISSING"}
+	}
+}
+
+// argumentsError returns the ARGUMENTS_ERROR the API would return for the missing arguments of
+// req, nil when there are none
+func argumentsError(method, path string, missing map[string][]string, req interface{}) error {
+	if len(missing) == 0 {
+		return nil
+	}
+
+	err := &Error{
+		Err:         ErrArguments.Err,
+		Description: "There are errors with your arguments.",
+		Method:      method,
+		Path:        path,
+		Arguments:   missing,
+	}
+	err.Fields = fieldErrors(err.Arguments, req)
+	return err
 }
 
 // EventUpdateDisplaySettings is the request structure for updating an Event
@@ -268,103 +338,73 @@ type EventUpdateDisplaySettings struct {
 //
 // https://www.eventbrite.com/developer/v3/endpoints/events/#ebapi-id20
 type EventGetTicketClass struct {
+	PageRequest
+
 	// Only return ticket classes valid for the given point of sale (Valid choices are: online, or at_the_door)
 	Pos string `json:"pos"`
 }
 
-// EventGetTicketClass is the request structure to create an Event TicketClass
+// EventCreateTicketClass is the request structure to create an Event TicketClass. The name of
+// the ticket class is required.
 //
 // https://www.eventbrite.com/developer/v3/endpoints/events/#ebapi-id22
 type EventCreateTicketClass struct {
-	// Name of this ticket type
-	Name string `json:"ticket_class.name"`
-	// Description of the ticket
-	Description string `json:"ticket_class.description"`
-	// Total available number of this ticket
-	QuantityTotal int `json:"quantity_total"`
-	// Cost of the ticket (currently currency must match event currency) e.g. $45 would be ‘USD,4500’
-	Cost Currency `json:"ticket_class.quantity_total"`
-	// Is this a donation? (user-supplied cost)
-	Donation bool `json:"ticket_class.donation"`
-	// If the ticket is a free ticket
-	Free bool `json:"ticket_class.free"`
-	// Absorb the fee into the displayed cost
-	IncludeFee bool `json:"ticket_class.include_fee"`
-	// Absorb the payment fee, but show the eventbrite fee
-	SplitFee bool `json:"ticket_class.split_fee"`
-	// Hide the ticket description on the event page
-	HideDescription bool `json:"ticket_class.hide_description"`
-	// A list of all supported sales channels ([“online”], [“online”, “atd”], [“atd”])
-	SalesChannels []interface{} `json:"ticket_class.sales_channels"`
-	// When the ticket is available for sale (leave empty for ‘when event published’)
-	SalesStart string `json:"ticket_class.sales_start"`
-	// When the ticket stops being on sale (leave empty for ‘one hour before event start’)
-	SalesEnd string `json:"ticket_class.sales_end"`
-	// The ID of another ticket class - when it sells out, this class will go on sale.
-	SalesStartAfter string `json:"ticket_class.sales_start_after"`
-	// Minimum number that can be bought per order
-	MinimumQuantity int `json:"ticket_class.minimum_quantity"`
-	// Maximum number that can be bought per order
-	MaximumQuantity int `json:"ticket_class.maximum_quantity"`
-	// How many of these tickets have already been sold and confirmed (does not include tickets being checked out right now)
-	QuantitySold int `json:"quantity_sold"`
-	// Hide this ticket
-	Hidden bool `json:"ticket_class.hidden"`
-	// Hide this ticket when it is not on sale
-	AutoHide bool `json:"ticket_class.auto_hide"`
-	// Override reveal date for auto-hide
-	AutoHideBefore string `json:"ticket_class.auto_hide_before"`
-	// Override re-hide date for auto-hide
-	AutoHideAfter string `json:"ticket_class.auto_hide_after"`
-	// Order message per ticket type
-	OrderConfirmationMessage string `json:"ticket_class.order_confirmation_message"`
+	TicketClass TicketClassFields `json:"ticket_class"`
 }
 
-// EventUpdateTicketClass is the request structure to update an Event TicketClass
+// EventUpdateTicketClass is the request structure to update an Event TicketClass. Only the fields
+// which are set are changed.
+//
 // https://www.eventbrite.com/developer/v3/endpoints/events/#ebapi-id26
 type EventUpdateTicketClass struct {
+	TicketClass TicketClassFields `json:"ticket_class"`
+}
+
+// TicketClassFields holds the writable fields of a TicketClass. Fields left to their zero value
+// are not sent, booleans are pointers so that false can be sent explicitly, see Bool, and so is
+// SalesStartAfter so that it can be cleared, see String.
+type TicketClassFields struct {
 	// Name of this ticket type
-	Name string `json:"ticket_class.name"`
+	Name string `json:"name,omitempty"`
 	// Description of the ticket
-	Description string `json:"ticket_class.description"`
+	Description string `json:"description,omitempty"`
 	// Total available number of this ticket
-	QuantityTotal int `json:"quantity_total"`
+	QuantityTotal int `json:"quantity_total,omitempty"`
 	// Cost of the ticket (currently currency must match event currency) e.g. $45 would be ‘USD,4500’
-	Cost Currency `json:"ticket_class.quantity_total"`
+	Cost string `json:"cost,omitempty"`
 	// Is this a donation? (user-supplied cost)
-	Donation bool `json:"ticket_class.donation"`
+	Donation *bool `json:"donation,omitempty"`
 	// If the ticket is a free ticket
-	Free bool `json:"ticket_class.free"`
+	Free *bool `json:"free,omitempty"`
 	// Absorb the fee into the displayed cost
-	IncludeFee bool `json:"ticket_class.include_fee"`
+	IncludeFee *bool `json:"include_fee,omitempty"`
 	// Absorb the payment fee, but show the eventbrite fee
-	SplitFee bool `json:"ticket_class.split_fee"`
+	SplitFee *bool `json:"split_fee,omitempty"`
 	// Hide the ticket description on the event page
-	HideDescription bool `json:"ticket_class.hide_description"`
+	HideDescription *bool `json:"hide_description,omitempty"`
 	// A list of all supported sales channels ([“online”], [“online”, “atd”], [“atd”])
-	SalesChannels []interface{} `json:"ticket_class.sales_channels"`
+	SalesChannels []string `json:"sales_channels,omitempty"`
 	// When the ticket is available for sale (leave empty for ‘when event published’)
-	SalesStart string `json:"ticket_class.sales_start"`
+	SalesStart string `json:"sales_start,omitempty"`
 	// When the ticket stops being on sale (leave empty for ‘one hour before event start’)
-	SalesEnd string `json:"ticket_class.sales_end"`
-	// The ID of another ticket class - when it sells out, this class will go on sale.
-	SalesStartAfter string `json:"ticket_class.sales_start_after"`
+	SalesEnd string `json:"sales_end,omitempty"`
+	// The ID of another ticket class - when it sells out, this class will go on sale. An empty
+	// ID clears it.
+	SalesStartAfter *string `json:"sales_start_after,omitempty"`
 	// Minimum number that can be bought per order
-	MinimumQuantity int `json:"ticket_class.minimum_quantity"`
+	MinimumQuantity int `json:"minimum_quantity,omitempty"`
 	// Maximum number that can be bought per order
-	MaximumQuantity int `json:"ticket_class.maximum_quantity"`
-	// How many of these tickets have already been sold and confirmed (does not include tickets being checked out right now)
-	QuantitySold int `json:"quantity_sold"`
+	MaximumQuantity int `json:"maximum_quantity,omitempty"`
 	// Hide this ticket
-	Hidden bool `json:"ticket_class.hidden"`
+	Hidden *bool `json:"hidden,omitempty"`
 	// Hide this ticket when it is not on sale
-	AutoHide bool `json:"ticket_class.auto_hide"`
+	AutoHide *bool `json:"auto_hide,omitempty"`
 	// Override reveal date for auto-hide
-	AutoHideBefore string `json:"ticket_class.auto_hide_before"`
+	AutoHideBefore string `json:"auto_hide_before,omitempty"`
 	// Override re-hide date for auto-hide
-	AutoHideAfter string `json:"ticket_class.auto_hide_after"`
+	AutoHideAfter string `json:"auto_hide_after,omitempty"`
 	// Order message per ticket type
-	OrderConfirmationMessage string `json:"ticket_class.order_confirmation_message"`
+	OrderConfirmationMessage string `json:"order_confirmation_message,omitempty"`
 }
 
 // EventDeleteTicketClass is the request structure to delkete an Event TicketClass
@@ -448,13 +488,15 @@ type EventCreateQuestion struct {
 //
 // https://www.eventbrite.com/developer/v3/endpoints/events/#ebapi-id41
 type EventGetAttendees struct {
+	PageRequest
+
 	// Limits results to either confirmed attendees or cancelled/refunded/etc.
 	// attendees (Valid choices are: attending, not_attending, or unpaid)
 	Status string `json:"status"`
 	// Only return attendees changed on or after the time given
 	ChangedSince string `json:"changed_since"`
 	// Only return attendees changed on or after the time given and with an id bigger than last item seen
-	LastItemSeen int `json:"last_item_seen"`
+	LastItemSeen int `json:"last_item_seen" url:",omitempty"`
 	// Only return attendees whose ids are in this list
 	AttendeeIds []interface{} `json:"attendee_ids"`
 }
@@ -463,17 +505,19 @@ type EventGetAttendees struct {
 //
 // https://www.eventbrite.com/developer/v3/endpoints/events/#ebapi-id45
 type EventGetOrders struct {
+	PageRequest
+
 	// Limits results to either confirmed attendees or cancelled/refunded/etc.
 	// attendees (Valid choices are: attending, not_attending, or unpaid)
 	Status string `json:"status"`
 	// Only return attendees changed on or after the time given
 	ChangedSince string `json:"changed_since"`
 	// Only return attendees changed on or after the time given and with an id bigger than last item seen
-	LastItemSeen int `json:"last_item_seen"`
+	LastItemSeen int `json:"last_item_seen" url:",omitempty"`
 	// Only include orders placed by one of these emails
 	OnlyEmails []interface{} `json:"only_emails"`
 	// Don’t include orders placed by any of these emails
-	ExcludeEmails []interface{} `json:"only_emails"`
+	ExcludeEmails []interface{} `json:"exclude_emails"`
 	// Return only orders with selected refund requests statuses.
 	// Possible values are: completed, pending, outside_policy, disputed, denied
 	RefundRequestStatuses []interface{} `json:"refund_request_statuses"`
@@ -483,6 +527,9 @@ type EventGetOrders struct {
 //
 // https://www.eventbrite.com/developer/v3/endpoints/events/#ebapi-id61
 type EventGetTransfers struct {
+	PageRequest
+
+	// Only return transfers changed on or after the time given
 	ChangedSince string `json:"changed_since"`
 }
 
@@ -490,6 +537,8 @@ type EventGetTransfers struct {
 //
 // https://www.eventbrite.com/developer/v3/endpoints/events/#ebapi-get-events-event-id-ticket-groups
 type EventGetTicketGroups struct {
+	PageRequest
+
 	// Limits results to groups with the specific status (Valid choices are: live, archived, deleted, or all)
 	Status string `json:"status"`
 }
@@ -498,6 +547,8 @@ type EventGetTicketGroups struct {
 //
 // https://www.eventbrite.com/developer/v3/endpoints/events/#ebapi-get-events-event-id-ticket-classes-ticket-class-id-ticket-groups
 type EventGetTicketGroupsTicketClasses struct {
+	PageRequest
+
 	// Limits results to groups with the specific status (Valid choices are: live, archived, deleted, or all)
 	Status string `json:"status"`
 }
@@ -519,6 +570,38 @@ type EventGetTicketClassResult struct {
 	TicketClasses []TicketClass `json:"ticket_classes"`
 }
 
+// EventAttendeesResult is the response structure for an Event Attendee list
+//
+// https://www.eventbrite.com/developer/v3/endpoints/events/#ebapi-get-events-id-attendees
+type EventAttendeesResult struct {
+	Pagination Pagination `json:"pagination"`
+	Attendees  []Attendee `json:"attendees"`
+}
+
+// EventOrdersResult is the response structure for an Event Order list
+//
+// https://www.eventbrite.com/developer/v3/endpoints/events/#ebapi-get-events-id-orders
+type EventOrdersResult struct {
+	Pagination Pagination `json:"pagination"`
+	Orders     []Order    `json:"orders"`
+}
+
+// EventTransfersResult is the response structure for an Event Transfer list
+//
+// https://www.eventbrite.com/developer/v3/endpoints/events/#ebapi-get-events-id-transfers
+type EventTransfersResult struct {
+	Pagination Pagination `json:"pagination"`
+	Transfers  []Transfer `json:"transfers"`
+}
+
+// EventTicketGroupsResult is the response structure for a TicketGroup list
+//
+// https://www.eventbrite.com/developer/v3/endpoints/events/#ebapi-get-events-event-id-ticket-groups
+type EventTicketGroupsResult struct {
+	Pagination   Pagination    `json:"pagination"`
+	TicketGroups []TicketGroup `json:"ticket_groups"`
+}
+
 // EventSearch allows you to retrieve a paginated response of public event objects from across
 // Eventbrite’s directory, regardless of which user owns the event.
 //
@@ -526,7 +609,18 @@ type EventGetTicketClassResult struct {
 func (c *Client) EventSearch(ctx context.Context, req *EventSearchRequest) (*EventSearchResult, error) {
 	result := &EventSearchResult{}
 
-	return result, c.getJSON(ctx, "/events/search/", req, &result)
+	return result, c.getJSON(ctx, "EventSearch", "/events/search/", req, &result)
+}
+
+// EventSearchIterator returns an Iterator over every event matched by EventSearch
+func (c *Client) EventSearchIterator(ctx context.Context, req *EventSearchRequest) *Iterator[Event] {
+	return iterate(ctx, req, func(ctx context.Context, r *EventSearchRequest) ([]Event, Pagination, error) {
+		res, err := c.EventSearch(ctx, r)
+		if err != nil {
+			return nil, Pagination{}, err
+		}
+		return res.Events, res.Pagination, nil
+	})
 }
 
 // EventGet returns an event for the specified event. Many of Eventbrite’s API use cases revolve around pulling
@@ -537,27 +631,30 @@ func (c *Client) EventSearch(ctx context.Context, req *EventSearchRequest) (*Eve
 func (c *Client) EventGet(ctx context.Context, id string) (*Event, error) {
 	result := &Event{}
 
-	return result, c.getJSON(ctx, "/events/"+id, url.Values{}, &result)
+	return result, c.getJSON(ctx, "EventGet", "/events/"+id, url.Values{}, &result)
 }
 
 // EventCreate makes a new event, and returns an event for the specified event. Does not support the
 // creation of repeating event series.
 //
 // https://www.eventbrite.com/developer/v3/endpoints/events/#ebapi-post-events
-func (c *Client) EventCreate(ctx context.Context, req *EventCreateRequest) (interface{}, error) {
-	var resp interface{}
+func (c *Client) EventCreate(ctx context.Context, req *EventCreateRequest) (*Event, error) {
+	if err := req.validate(); err != nil {
+		return nil, err
+	}
 
-	return resp, c.postJSON(ctx, "/events/", req, &resp)
+	event := new(Event)
+	return event, c.postJSON(ctx, "EventCreate", "/events/", req, event)
 }
 
 // EventUpdate updates an event. Returns an event for the specified event. Does not support updating a
 // repeating event series parent (see POST /series/:id/)
 //
 // https://www.eventbrite.com/developer/v3/endpoints/events/#ebapi-post-events-id
-func (c *Client) EventUpdate(ctx context.Context, id string, req *EventUpdateRequest) (interface{}, error) {
-	event := &Event{}
+func (c *Client) EventUpdate(ctx context.Context, id string, req *EventUpdateRequest) (*Event, error) {
+	event := new(Event)
 
-	return event, c.postJSON(ctx, fmt.Sprintf("/events/%s/", id), req, event)
+	return event, c.postJSON(ctx, "EventUpdate", fmt.Sprintf("/events/%s/", id), req, event)
 }
 
 // EventPublish publishes an event if it has not already been deleted. In order for publish to be permitted, the event
@@ -570,7 +667,7 @@ func (c *Client) EventPublish(ctx context.Context, id string) (interface{}, erro
 	path := fmt.Sprintf("/events/%s/publish", id)
 
 	var resp interface{}
-	return resp, c.postJSON(ctx, path, nil, &resp)
+	return resp, c.postJSON(ctx, "EventPublish", path, nil, &resp)
 }
 
 // EventUnPublish unpublishes an event. In order for a free event to be unpublished, it must not have any pending or completed
@@ -583,7 +680,7 @@ func (c *Client) EventUnPublish(ctx context.Context, id string) (interface{}, er
 	path := fmt.Sprintf("/events/%s/unpublish", id)
 
 	var resp interface{}
-	return resp, c.postJSON(ctx, path, nil, &resp)
+	return resp, c.postJSON(ctx, "EventUnPublish", path, nil, &resp)
 }
 
 // EventCancel cancels an event if it has not already been deleted. In order for cancel to be permitted, there must be no
@@ -591,10 +688,10 @@ func (c *Client) EventUnPublish(ctx context.Context, id string) (interface{}, er
 //
 // https://www.eventbrite.com/developer/v3/endpoints/events/#ebapi-post-events-id-cancel
 func (c *Client) EventCancel(ctx context.Context, id string) (interface{}, error) {
-	path := fmt.Sprintf("/events/%s/unpublish", id)
+	path := fmt.Sprintf("/events/%s/cancel", id)
 
 	var resp interface{}
-	return resp, c.postJSON(ctx, path, nil, &resp)
+	return resp, c.postJSON(ctx, "EventCancel", path, nil, &resp)
 }
 
 // EventDelete deletes an event if the delete is permitted. In order for a delete to be permitted, there must be no pending
@@ -605,7 +702,7 @@ func (c *Client) EventDelete(ctx context.Context, id string) (interface{}, error
 	path := fmt.Sprintf("/events/%s", id)
 
 	var resp interface{}
-	return resp, c.deleteJSON(ctx, path, &resp)
+	return resp, c.deleteJSON(ctx, "EventDelete", path, &resp)
 }
 
 // EventGetDisplaySettings gets Event display settings
@@ -614,7 +711,7 @@ func (c *Client) EventDelete(ctx context.Context, id string) (interface{}, error
 func (c *Client) EventGetDisplaySettings(ctx context.Context, id string) (*EventSettings, error) {
 	result := new(EventSettings)
 
-	return result, c.getJSON(ctx, fmt.Sprintf("/events/%s/display_settings/", id), url.Values{}, &result)
+	return result, c.getJSON(ctx, "EventGetDisplaySettings", fmt.Sprintf("/events/%s/display_settings/", id), url.Values{}, &result)
 }
 
 // EventUpdateDisplaySettings apdates the display settings for an Event.
@@ -623,7 +720,7 @@ func (c *Client) EventGetDisplaySettings(ctx context.Context, id string) (*Event
 func (c *Client) EventUpdateDisplaySettings(ctx context.Context, id string, settings *EventUpdateDisplaySettings) (*EventSettings, error) {
 	result := new(EventSettings)
 
-	return result, c.postJSON(ctx, fmt.Sprintf("/events/%s/display_settings/", id), settings, &result)
+	return result, c.postJSON(ctx, "EventUpdateDisplaySettings", fmt.Sprintf("/events/%s/display_settings/", id), settings, &result)
 }
 
 // EventGetTicketClasses gets an Event TicketClass
@@ -632,7 +729,18 @@ func (c *Client) EventUpdateDisplaySettings(ctx context.Context, id string, sett
 func (c *Client) EventGetTicketClasses(ctx context.Context, id string, class *EventGetTicketClass) (*EventGetTicketClassResult, error) {
 	result := new(EventGetTicketClassResult)
 
-	return result, c.getJSON(ctx, fmt.Sprintf("/events/%s/ticket_classes/", id), class, result)
+	return result, c.getJSON(ctx, "EventGetTicketClasses", fmt.Sprintf("/events/%s/ticket_classes/", id), class, result)
+}
+
+// EventTicketClassesIterator returns an Iterator over every ticket class of the event
+func (c *Client) EventTicketClassesIterator(ctx context.Context, id string, class *EventGetTicketClass) *Iterator[TicketClass] {
+	return iterate(ctx, class, func(ctx context.Context, r *EventGetTicketClass) ([]TicketClass, Pagination, error) {
+		res, err := c.EventGetTicketClasses(ctx, id, r)
+		if err != nil {
+			return nil, Pagination{}, err
+		}
+		return res.TicketClasses, res.Pagination, nil
+	})
 }
 
 // EventCreateTicketClass creates a new ticket class, returning the result as a ticket_class under the key ticket_class.
@@ -641,7 +749,7 @@ func (c *Client) EventGetTicketClasses(ctx context.Context, id string, class *Ev
 func (c *Client) EventCreateTicketClass(ctx context.Context, id string, class *EventCreateTicketClass) (*TicketClass, error) {
 	result := new(TicketClass)
 
-	return result, c.postJSON(ctx, fmt.Sprintf("/events/%s/ticket_classes/", id), class, result)
+	return result, c.postJSON(ctx, "EventCreateTicketClass", fmt.Sprintf("/events/%s/ticket_classes/", id), class, result)
 }
 
 // EventGetTicketClass gets and returns a single TicketClass by ID
@@ -650,7 +758,7 @@ func (c *Client) EventCreateTicketClass(ctx context.Context, id string, class *E
 func (c *Client) EventGetTicketClass(ctx context.Context, eventId, ticketId string) (*TicketClass, error) {
 	result := new(TicketClass)
 
-	return result, c.getJSON(ctx, fmt.Sprintf("/events/%s/ticket_classes/%s/", eventId, ticketId), nil, result)
+	return result, c.getJSON(ctx, "EventGetTicketClass", fmt.Sprintf("/events/%s/ticket_classes/%s/", eventId, ticketId), nil, result)
 }
 
 // EventUpdateTicketClass updates an existing ticket class, returning the updated result as a ticket_class under the key
@@ -659,7 +767,7 @@ func (c *Client) EventGetTicketClass(ctx context.Context, eventId, ticketId stri
 func (c *Client) EventUpdateTicketClass(ctx context.Context, eventId, ticketId string, class *EventUpdateTicketClass) (*TicketClass, error) {
 	result := new(TicketClass)
 
-	return result, c.postJSON(ctx, fmt.Sprintf("/events/%s/ticket_classes/%s/", eventId, ticketId), nil, result)
+	return result, c.postJSON(ctx, "EventUpdateTicketClass", fmt.Sprintf("/events/%s/ticket_classes/%s/", eventId, ticketId), class, result)
 }
 
 // EventDeleteTicketClass deletes the ticket class. Returns {"deleted": true}
@@ -668,7 +776,7 @@ func (c *Client) EventUpdateTicketClass(ctx context.Context, eventId, ticketId s
 func (c *Client) EventDeleteTicketClass(ctx context.Context, eventId, ticketId string, class *EventDeleteTicketClass) (interface{}, error) {
 	result := new(TicketClass)
 
-	return result, c.deleteJSON(ctx, fmt.Sprintf("/events/%s/ticket_classes/%s/", eventId, ticketId), result)
+	return result, c.deleteJSON(ctx, "EventDeleteTicketClass", fmt.Sprintf("/events/%s/ticket_classes/%s/", eventId, ticketId), result)
 }
 
 // EventGetCannedQuestions this endpoint returns canned questions of a single event
@@ -678,7 +786,7 @@ func (c *Client) EventDeleteTicketClass(ctx context.Context, eventId, ticketId s
 func (c *Client) EventGetCannedQuestions(ctx context.Context, id string, q *EventGetCannedQuestions) (interface{}, error) {
 	var result interface{}
 
-	return result, c.getJSON(ctx, fmt.Sprintf("/events/%s/canned_questions/", id), q, result)
+	return result, c.getJSON(ctx, "EventGetCannedQuestions", fmt.Sprintf("/events/%s/canned_questions/", id), q, result)
 }
 
 // EventCreateCannedQuestion creates a new canned question; returns the result as a question
@@ -687,7 +795,7 @@ func (c *Client) EventGetCannedQuestions(ctx context.Context, id string, q *Even
 func (c *Client) EventCreateCannedQuestion(ctx context.Context, id string, q *EventCreateCannedQuestion) (interface{}, error) {
 	var result interface{}
 
-	return result, c.postJSON(ctx, fmt.Sprintf("/events/%s/canned_questions/", id), q, result)
+	return result, c.postJSON(ctx, "EventCreateCannedQuestion", fmt.Sprintf("/events/%s/canned_questions/", id), q, result)
 }
 
 // Eventbrite allows event organizers to add custom questions that attendees fill out upon registration.
@@ -698,7 +806,7 @@ func (c *Client) EventCreateCannedQuestion(ctx context.Context, id string, q *Ev
 func (c *Client) EventGetQuestions(ctx context.Context, id string, q *EventGetQuestions) (interface{}, error) {
 	var result interface{}
 
-	return result, c.postJSON(ctx, fmt.Sprintf("/events/%s/questions/", id), q, result)
+	return result, c.postJSON(ctx, "EventGetQuestions", fmt.Sprintf("/events/%s/questions/", id), q, result)
 }
 
 // EventCreateQuestion creates a new question; returns the result as a question as the key question
@@ -707,7 +815,7 @@ func (c *Client) EventGetQuestions(ctx context.Context, id string, q *EventGetQu
 func (c *Client) EventCreateQuestion(ctx context.Context, id string, q *EventCreateQuestion) (interface{}, error) {
 	var result interface{}
 
-	return result, c.postJSON(ctx, fmt.Sprintf("/events/%s/questions/", id), q, result)
+	return result, c.postJSON(ctx, "EventCreateQuestion", fmt.Sprintf("/events/%s/questions/", id), q, result)
 }
 
 // EventGetQuestion returns question for a specific question id
@@ -716,5 +824,114 @@ func (c *Client) EventCreateQuestion(ctx context.Context, id string, q *EventCre
 func (c *Client) EventGetQuestion(ctx context.Context, eventId, questionId string) (interface{}, error) {
 	var result interface{}
 
-	return result, c.postJSON(ctx, fmt.Sprintf("/events/%s/questions/%s/", eventId, questionId), nil, result)
+	return result, c.postJSON(ctx, "EventGetQuestion", fmt.Sprintf("/events/%s/questions/%s/", eventId, questionId), nil, result)
+}
+
+// EventAttendees returns a paginated response with a key of attendees, containing a list of attendee
+//
+// https://www.eventbrite.com/developer/v3/endpoints/events/#ebapi-get-events-id-attendees
+func (c *Client) EventAttendees(ctx context.Context, id string, req *EventGetAttendees) (*EventAttendeesResult, error) {
+	result := new(EventAttendeesResult)
+
+	return result, c.getJSON(ctx, "EventAttendees", fmt.Sprintf("/events/%s/attendees/", id), req, result)
+}
+
+// EventAttendeesIterator returns an Iterator over every attendee of the event
+func (c *Client) EventAttendeesIterator(ctx context.Context, id string, req *EventGetAttendees) *Iterator[Attendee] {
+	return iterate(ctx, req, func(ctx context.Context, r *EventGetAttendees) ([]Attendee, Pagination, error) {
+		res, err := c.EventAttendees(ctx, id, r)
+		if err != nil {
+			return nil, Pagination{}, err
+		}
+		return res.Attendees, res.Pagination, nil
+	})
+}
+
+// EventAttendee returns a single attendee by ID, as the key attendee
+//
+// https://www.eventbrite.com/developer/v3/endpoints/events/#ebapi-get-events-id-attendees-attendee-id
+func (c *Client) EventAttendee(ctx context.Context, eventId, attendeeId string) (*Attendee, error) {
+	result := new(Attendee)
+
+	return result, c.getJSON(ctx, "EventAttendee", fmt.Sprintf("/events/%s/attendees/%s/", eventId, attendeeId), nil, result)
+}
+
+// EventOrders returns a paginated response with a key of orders, containing a list of order against this event
+//
+// https://www.eventbrite.com/developer/v3/endpoints/events/#ebapi-get-events-id-orders
+func (c *Client) EventOrders(ctx context.Context, id string, req *EventGetOrders) (*EventOrdersResult, error) {
+	result := new(EventOrdersResult)
+
+	return result, c.getJSON(ctx, "EventOrders", fmt.Sprintf("/events/%s/orders/", id), req, result)
+}
+
+// EventOrdersIterator returns an Iterator over every order placed against the event
+func (c *Client) EventOrdersIterator(ctx context.Context, id string, req *EventGetOrders) *Iterator[Order] {
+	return iterate(ctx, req, func(ctx context.Context, r *EventGetOrders) ([]Order, Pagination, error) {
+		res, err := c.EventOrders(ctx, id, r)
+		if err != nil {
+			return nil, Pagination{}, err
+		}
+		return res.Orders, res.Pagination, nil
+	})
+}
+
+// EventTransfers returns a list of transfers for the event
+//
+// https://www.eventbrite.com/developer/v3/endpoints/events/#ebapi-get-events-id-transfers
+func (c *Client) EventTransfers(ctx context.Context, id string, req *EventGetTransfers) (*EventTransfersResult, error) {
+	result := new(EventTransfersResult)
+
+	return result, c.getJSON(ctx, "EventTransfers", fmt.Sprintf("/events/%s/transfers/", id), req, result)
+}
+
+// EventTransfersIterator returns an Iterator over every transfer of the event
+func (c *Client) EventTransfersIterator(ctx context.Context, id string, req *EventGetTransfers) *Iterator[Transfer] {
+	return iterate(ctx, req, func(ctx context.Context, r *EventGetTransfers) ([]Transfer, Pagination, error) {
+		res, err := c.EventTransfers(ctx, id, r)
+		if err != nil {
+			return nil, Pagination{}, err
+		}
+		return res.Transfers, res.Pagination, nil
+	})
+}
+
+// EventTicketGroups returns a list of ticket_group for that event. Returns only the fields id and name
+//
+// https://www.eventbrite.com/developer/v3/endpoints/events/#ebapi-get-events-event-id-ticket-groups
+func (c *Client) EventTicketGroups(ctx context.Context, id string, req *EventGetTicketGroups) (*EventTicketGroupsResult, error) {
+	result := new(EventTicketGroupsResult)
+
+	return result, c.getJSON(ctx, "EventTicketGroups", fmt.Sprintf("/events/%s/ticket_groups/", id), req, result)
+}
+
+// EventTicketGroupsIterator returns an Iterator over every ticket group of the event
+func (c *Client) EventTicketGroupsIterator(ctx context.Context, id string, req *EventGetTicketGroups) *Iterator[TicketGroup] {
+	return iterate(ctx, req, func(ctx context.Context, r *EventGetTicketGroups) ([]TicketGroup, Pagination, error) {
+		res, err := c.EventTicketGroups(ctx, id, r)
+		if err != nil {
+			return nil, Pagination{}, err
+		}
+		return res.TicketGroups, res.Pagination, nil
+	})
+}
+
+// EventTicketClassTicketGroups returns a list of ticket_group for that ticket class
+//
+// https://www.eventbrite.com/developer/v3/endpoints/events/#ebapi-get-events-event-id-ticket-classes-ticket-class-id-ticket-groups
+func (c *Client) EventTicketClassTicketGroups(ctx context.Context, eventId, ticketId string, req *EventGetTicketGroupsTicketClasses) (*EventTicketGroupsResult, error) {
+	result := new(EventTicketGroupsResult)
+
+	return result, c.getJSON(ctx, "EventTicketClassTicketGroups", fmt.Sprintf("/events/%s/ticket_classes/%s/ticket_groups/", eventId, ticketId), req, result)
+}
+
+// EventTicketClassTicketGroupsIterator returns an Iterator over every ticket group of the ticket class
+func (c *Client) EventTicketClassTicketGroupsIterator(ctx context.Context, eventId, ticketId string, req *EventGetTicketGroupsTicketClasses) *Iterator[TicketGroup] {
+	return iterate(ctx, req, func(ctx context.Context, r *EventGetTicketGroupsTicketClasses) ([]TicketGroup, Pagination, error) {
+		res, err := c.EventTicketClassTicketGroups(ctx, eventId, ticketId, r)
+		if err != nil {
+			return nil, Pagination{}, err
+		}
+		return res.TicketGroups, res.Pagination, nil
+	})
 }
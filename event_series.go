@@ -2,74 +2,57 @@ package eventbrite
 
 import (
 	"fmt"
+	"net/http"
+
 	"golang.org/x/net/context"
 )
 
+// SeriesCreateEventRequest is the request structure for creating a repeating event series. The
+// name, start, end and currency of the series parent are required, along with at least one date.
+//
 // https://www.eventbrite.co.uk/developer/v3/endpoints/events_series/#ebapi-parameters
 type SeriesCreateEventRequest struct {
-	// The name of the event
-	Name string `json:"series_parent.name.html" validate:"required"`
-	// The description on the event page
-	Description string `json:"series_parent.description.html"`
-	// of the organizer of this event
-	OrganizerID string `json:"series_parent.organizer_id"`
-	// The start time of the event
-	StartUtc DateTime `json:"series_parent.start.utc" validate:"required"`
-	// Start time timezone (Olson format)
-	StartTimezone string `json:"series_parent.start.timezone" validate:"required"`
-	// The end time of the event
-	EndUtc DateTime `json:"series_parent.end.utc" validate:"required"`
-	// End time timezone (Olson format)
-	EndTimezone string `json:"series_parent.end.timezone" validate:"required"`
-	// Whether the start date should be hidden
-	HideStartDate bool `json:"series_parent.hide_start_date"`
-	// Whether the end date should be hidden
-	HideEndDate bool `json:"series_parent.hide_end_date"`
-	// Event currency (3 letter code)
-	Currency string `json:"series_parent.currency" validate:"required"`
-	// ID of the venue
-	VenueID string `json:"series_parent.venue_id"`
-	// Is the event online-only (no venue)?
-	OnlineEvent bool `json:"series_parent.online_event"`
-	// If the event is publicly listed and searchable. Defaults to true
-	Listed bool `json:"series_parent.listed"`
-	// (Deprecated) The logo for the event
-	LogoID string `json:"series_parent.logo.id"`
-	// The category (vertical) of the event
-	CategoryID string `json:"series_parent.category_id"`
-	// The subcategory of the event (US only)
-	SubCategoryID string `json:"series_parent.subcategory_id"`
-	// The format (general type) of the event
-	FormatID string `json:"series_parent.format_id"`
-	// If users can share the event on social media
-	Sharable bool `json:"series_parent.shareable"`
-	// Only invited users can see the event page
-	InviteOnly bool `json:"series_parent.invite_only"`
-	// Password needed to see the event in unlisted mode
-	Password string `json:"series_parent.password"`
-	// Set specific capacity (if omitted, sums ticket capacities)
-	Capacity int `json:"series_parent.capacity"`
-	// If the remaining number of tickets is publicly visible on the event page
-	ShowRemaining bool `json:"series_parent.show_remaining"`
-	// A list of dates for which child events should be created. In the format:
-	// [
-	//   {
-	//     "start": { "utc": "2015-06-15T12:00:00Z", "timezone": "America/Los_Angeles" },
-	//     "end": { "utc": "2015-06-15T13:00:00Z", "timezone": "America/Los_Angeles" } },
-	//     { ... },
-	//     ...
-	// ]
-	//
-	// https://www.eventbrite.co.uk/developer/v3/response_formats/basic/#ebapi-std:format-objectlist
-	CreateChildren interface{} `json:"create_children" validate:"required"`
+	// The fields of the series parent, the dates of the series inherit them
+	SeriesParent EventFields `json:"series_parent"`
+	// The dates for which child events should be created, see Recurrence to generate them
+	CreateChildren []SeriesDate `json:"create_children"`
+}
+
+// SeriesDate is the start and end of a date of a repeating event series
+//
+// https://www.eventbrite.co.uk/developer/v3/response_formats/basic/#ebapi-std:format-objectlist
+type SeriesDate struct {
+	Start EventTime `json:"start"`
+	End   EventTime `json:"end"`
+}
+
+// validate reports the missing fields of the request the way the API does, as an ARGUMENTS_ERROR.
+// A nil request misses all of them.
+func (r *SeriesCreateEventRequest) validate() error {
+	if r == nil {
+		r = &SeriesCreateEventRequest{}
+	}
+	missing := map[string][]string{}
+	r.SeriesParent.addMissing("series_parent.", missing)
+	if len(r.CreateChildren) == 0 {
+		missing["create_children"] = []string{"MISSING"}
+	}
+
+	return argumentsError(http.MethodPost, "/series/", missing, r)
 }
 
+// ObjectList is a list of objects of any kind, the objectlist format of the API. The dates of a
+// series are typed as SeriesDate instead.
+//
+// https://www.eventbrite.co.uk/developer/v3/response_formats/basic/#ebapi-std:format-objectlist
 type ObjectList []interface{}
 
-// SeriesEventRequest is the response structure for series event
+// SeriesEventRequest is the request structure for listing the dates of a series
 //
 // https://www.eventbrite.co.uk/developer/v3/endpoints/events_series/#ebapi-id14
 type SeriesEventRequest struct {
+	PageRequest
+
 	// Limits results to either past or current & future events. (Valid choices are: all, past, or current_future)
 	TimeFilter string `json:"time_filter"`
 	// Append the given tracking_code to the event URLs returned
@@ -78,58 +61,73 @@ type SeriesEventRequest struct {
 	OrderBy string `json:"order_by"`
 }
 
+// SeriesEventsResult is the response structure for the dates of a series
+type SeriesEventsResult struct {
+	Pagination Pagination `json:"pagination"`
+	Events     []Event    `json:"events"`
+}
+
 // SeriesCUREventRequest is the request structure to make create, update, delete
-// requests
+// requests. DiffSeries builds it from the dates a series should have.
 //
 // https://www.eventbrite.co.uk/developer/v3/endpoints/events_series/#ebapi-id16
 type SeriesCUREventRequest struct {
-	// A list of dates for which child events should be created. In the format:
-	//
-	//  [
-	//    {
-	//      "start": { "utc": "2015-06-15T12:00:00Z", "timezone": "America/Los_Angeles" },
-	//      "end": { "utc": "2015-06-15T13:00:00Z", "timezone": "America/Los_Angeles" }
-	//    },
-	//    { ... },
-	//    ...
-	//  ]
-	//
-	// https://www.eventbrite.co.uk/developer/v3/response_formats/basic/#ebapi-std:format-objectlist
-	CreateChildren interface{} `json:"create_children"`
-	// A map of event IDs to modified date objects for updating child events. In the format:
-	//
-	// {
-	//   "1234": { "start": { "utc": "2015-06-15T12:00:00Z", "timezone": "America/Los_Angeles" },
-	//   "end": { "utc": "2015-06-15T13:00:00Z", "timezone": "America/Los_Angeles" } },
-	//   "5678": { ... },
-	//   ...
-	// }
-	UpdateChildren interface{} `json:"create_children"`
-	// A list of IDs for child events that should be deleted. In the format: 1234,5678,9012
-	DeleteChildren []string `json:"delete_children"`
+	// The dates for which child events should be created
+	CreateChildren []SeriesDate `json:"create_children,omitempty"`
+	// The new dates of the child events to update, keyed by event ID
+	UpdateChildren map[string]SeriesDate `json:"update_children,omitempty"`
+	// The IDs of the child events that should be deleted
+	DeleteChildren []string `json:"delete_children,omitempty"`
+}
+
+// Empty reports whether the request changes nothing
+func (r *SeriesCUREventRequest) Empty() bool {
+	return len(r.CreateChildren) == 0 && len(r.UpdateChildren) == 0 && len(r.DeleteChildren) == 0
 }
 
 // EventSeriesCreate creates a new repeating event series. The POST data must include information for at
 // least one event date in the series.
 //
-// Return object is not documented
+// It returns the series parent.
 //
 // https://www.eventbrite.co.uk/developer/v3/endpoints/events_series/#ebapi-post-series
-func (c *Client) EventSeriesCreate(ctx context.Context, req *SeriesCreateEventRequest) (interface{}, error) {
-	var resp interface{}
+func (c *Client) EventSeriesCreate(ctx context.Context, req *SeriesCreateEventRequest) (*Event, error) {
+	if err := req.validate(); err != nil {
+		return nil, err
+	}
 
-	return resp, c.postJSON(ctx, "/series/", req, &resp)
+	event := new(Event)
+	return event, c.postJSON(ctx, "EventSeriesCreate", "/series/", req, event)
 }
 
 // EventSeriesGet returns a repeating event series parent object for the specified repeating event series
 //
-// Return object is not documented
-//
 // https://www.eventbrite.co.uk/developer/v3/endpoints/events_series/#ebapi-get-series-id
-func (c *Client) EventSeriesGet(ctx context.Context, id string) (interface{}, error) {
-	var resp interface{}
+func (c *Client) EventSeriesGet(ctx context.Context, id string) (*Event, error) {
+	event := new(Event)
 
-	return resp, c.getJSON(ctx, fmt.Sprintf("/series/%s", id), nil, &resp)
+	return event, c.getJSON(ctx, "EventSeriesGet", fmt.Sprintf("/series/%s/", id), nil, event)
+}
+
+// EventSeriesEvents returns a paginated response with a key of events, containing the dates of
+// the repeating event series
+//
+// https://www.eventbrite.co.uk/developer/v3/endpoints/events_series/#ebapi-get-series-id-events
+func (c *Client) EventSeriesEvents(ctx context.Context, id string, req *SeriesEventRequest) (*SeriesEventsResult, error) {
+	result := new(SeriesEventsResult)
+
+	return result, c.getJSON(ctx, "EventSeriesEvents", fmt.Sprintf("/series/%s/events/", id), req, result)
+}
+
+// EventSeriesEventsIterator returns an Iterator over every date of the repeating event series
+func (c *Client) EventSeriesEventsIterator(ctx context.Context, id string, req *SeriesEventRequest) *Iterator[Event] {
+	return iterate(ctx, req, func(ctx context.Context, r *SeriesEventRequest) ([]Event, Pagination, error) {
+		res, err := c.EventSeriesEvents(ctx, id, r)
+		if err != nil {
+			return nil, Pagination{}, err
+		}
+		return res.Events, res.Pagination, nil
+	})
 }
 
 // Publishes a repeating event series and all of its occurrences that are not already canceled or deleted.
@@ -144,7 +142,7 @@ func (c *Client) EventSeriesPublish(ctx context.Context, id string) (interface{}
 	path := fmt.Sprintf("/series/%s/publish", id)
 
 	var resp interface{}
-	return resp, c.postJSON(ctx, path, nil, &resp)
+	return resp, c.postJSON(ctx, "EventSeriesPublish", path, nil, &resp)
 }
 
 // Unpublishes a repeating event series and all of its occurrences that are not already completed, canceled,
@@ -158,7 +156,7 @@ func (c *Client) EventSeriesUnPublish(ctx context.Context, id string) (interface
 	path := fmt.Sprintf("/series/%s/unpublish", id)
 
 	var resp interface{}
-	return resp, c.postJSON(ctx, path, nil, &resp)
+	return resp, c.postJSON(ctx, "EventSeriesUnPublish", path, nil, &resp)
 }
 
 // Cancels a repeating event series and all of its occurrences that are not already canceled or deleted. In order
@@ -167,10 +165,10 @@ func (c *Client) EventSeriesUnPublish(ctx context.Context, id string) (interface
 //
 // https://www.eventbrite.co.uk/developer/v3/endpoints/events_series/#ebapi-post-series-id-cancel
 func (c *Client) EventSeriesCancel(ctx context.Context, id string) (interface{}, error) {
-	path := fmt.Sprintf("/series/%s/unpublish", id)
+	path := fmt.Sprintf("/series/%s/cancel", id)
 
 	var resp interface{}
-	return resp, c.postJSON(ctx, path, nil, &resp)
+	return resp, c.postJSON(ctx, "EventSeriesCancel", path, nil, &resp)
 }
 
 // Deletes a repeating event series and all of its occurrences if the delete is permitted. In order for a delete to
@@ -182,15 +180,16 @@ func (c *Client) EventSeriesDelete(ctx context.Context, id string) (interface{},
 	path := fmt.Sprintf("/series/%s", id)
 
 	var resp interface{}
-	return resp, c.deleteJSON(ctx, path, &resp)
+	return resp, c.deleteJSON(ctx, "EventSeriesDelete", path, &resp)
 }
 
 // Creates more event dates or updates or deletes existing event dates in a repeating event series. In order for a
-// series date to be deleted or updated, there must be no pending or completed orders for that date
+// series date to be deleted or updated, there must be no pending or completed orders for that date.
+// It returns the dates created or updated.
 //
 // https://www.eventbrite.co.uk/developer/v3/endpoints/events_series/#ebapi-post-series-id-events
-func (c *Client) EventSeriesCUD(ctx context.Context, id string, req *SeriesCUREventRequest) (interface{}, error) {
-	var v interface{}
+func (c *Client) EventSeriesCUD(ctx context.Context, id string, req *SeriesCUREventRequest) (*SeriesEventsResult, error) {
+	result := new(SeriesEventsResult)
 
-	return v, c.postJSON(ctx, fmt.Sprintf("/series/%s/events/", id), req, v)
+	return result, c.postJSON(ctx, "EventSeriesCUD", fmt.Sprintf("/series/%s/events/", id), req, result)
 }
@@ -0,0 +1,74 @@
+package eventbrite
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	"golang.org/x/net/context"
+)
+
+func TestCreateNilRequest(t *testing.T) {
+	clnt, err := NewClient(WithToken("token"), WithBaseURL("http://127.0.0.1:1"), WithRateLimit(0))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name string
+		call func() error
+		want string
+	}{
+		{"event", func() error {
+			_, err := clnt.EventCreate(context.Background(), nil)
+			return err
+		}, "event.name.html"},
+		{"series", func() error {
+			_, err := clnt.EventSeriesCreate(context.Background(), nil)
+			return err
+		}, "create_children"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.call()
+			var apiErr *Error
+			if !errors.As(err, &apiErr) || !errors.Is(err, ErrArguments) {
+				t.Fatalf("error = %v, want an ARGUMENTS_ERROR", err)
+			}
+			if _, ok := apiErr.Arguments[tt.want]; !ok {
+				t.Errorf("arguments = %v, want %s missing", apiErr.Arguments, tt.want)
+			}
+		})
+	}
+}
+
+func TestDateTimeMarshalJSON(t *testing.T) {
+	paris, err := time.LoadLocation("Europe/Paris")
+	if err != nil {
+		t.Skip(err)
+	}
+
+	tests := []struct {
+		name string
+		time time.Time
+		want string
+	}{
+		{"utc", time.Date(2026, 7, 1, 18, 30, 0, 0, time.UTC), `"2026-07-01T18:30:00Z"`},
+		{"local", time.Date(2026, 7, 1, 20, 30, 0, 0, paris), `"2026-07-01T18:30:00Z"`},
+		{"fixed offset", time.Date(2026, 7, 1, 13, 30, 0, 0, time.FixedZone("EST", -5*3600)), `"2026-07-01T18:30:00Z"`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := json.Marshal(DateTime{Time: tt.time})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if string(got) != tt.want {
+				t.Errorf("MarshalJSON() = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
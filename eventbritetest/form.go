@@ -0,0 +1,113 @@
+package eventbritetest
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+// form holds the fields of a request body flattened to dotted keys, so that both nested
+// objects like {"event": {"name": {"html": "..."}}} and dotted keys like {"event.name.html": "..."}
+// are read the same way
+type form map[string]interface{}
+
+func readBody(r *http.Request) (form, error) {
+	f := form{}
+	if r.Body == nil {
+		return f, nil
+	}
+
+	b, err := io.ReadAll(io.LimitReader(r.Body, 10<<20))
+	if err != nil {
+		return nil, err
+	}
+	if len(strings.TrimSpace(string(b))) == 0 || string(b) == "null" {
+		return f, nil
+	}
+
+	var v interface{}
+	if err := json.Unmarshal(b, &v); err != nil {
+		return nil, err
+	}
+	if m, ok := v.(map[string]interface{}); ok {
+		f.flatten("", m)
+	}
+	return f, nil
+}
+
+func (f form) flatten(prefix string, m map[string]interface{}) {
+	for k, v := range m {
+		if prefix != "" {
+			k = prefix + "." + k
+		}
+		if sub, ok := v.(map[string]interface{}); ok {
+			f.flatten(k, sub)
+			continue
+		}
+		f[k] = v
+	}
+}
+
+func (f form) has(key string) bool {
+	v, ok := f[key]
+	return ok && v != nil
+}
+
+func (f form) str(key string) string {
+	switch v := f[key].(type) {
+	case string:
+		return v
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	case bool:
+		return strconv.FormatBool(v)
+	}
+	return ""
+}
+
+func (f form) boolean(key string) bool {
+	switch v := f[key].(type) {
+	case bool:
+		return v
+	case string:
+		b, _ := strconv.ParseBool(v)
+		return b
+	}
+	return false
+}
+
+func (f form) number(key string) float64 {
+	switch v := f[key].(type) {
+	case float64:
+		return v
+	case string:
+		n, _ := strconv.ParseFloat(v, 64)
+		return n
+	}
+	return 0
+}
+
+func (f form) integer(key string) int {
+	return int(f.number(key))
+}
+
+// strings reads a list given either as a JSON array or as a comma separated string
+func (f form) strings(key string) []string {
+	switch v := f[key].(type) {
+	case []interface{}:
+		res := make([]string, 0, len(v))
+		for _, item := range v {
+			res = append(res, fmt.Sprint(item))
+		}
+		return res
+	case string:
+		if v == "" {
+			return nil
+		}
+		return strings.Split(v, ",")
+	}
+	return nil
+}
@@ -0,0 +1,772 @@
+package eventbritetest
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/apzuk3/go-eventbrite"
+)
+
+type route struct {
+	method  string
+	pattern string
+	handle  func(r *request, params []string)
+}
+
+func (s *Server) routes() []route {
+	return []route{
+		{http.MethodGet, "events/search", s.searchEvents},
+		{http.MethodPost, "events", s.createEvent},
+		{http.MethodGet, "events/*", s.getEvent},
+		{http.MethodPost, "events/*", s.updateEvent},
+		{http.MethodDelete, "events/*", s.deleteEvent},
+		{http.MethodPost, "events/*/publish", s.setEventStatus("live", "published")},
+		{http.MethodPost, "events/*/unpublish", s.setEventStatus("draft", "unpublished")},
+		{http.MethodPost, "events/*/cancel", s.setEventStatus("canceled", "canceled")},
+		{http.MethodGet, "events/*/ticket_classes", s.listTicketClasses},
+		{http.MethodPost, "events/*/ticket_classes", s.createTicketClass},
+		{http.MethodGet, "events/*/ticket_classes/*", s.getTicketClass},
+		{http.MethodPost, "events/*/ticket_classes/*", s.updateTicketClass},
+		{http.MethodDelete, "events/*/ticket_classes/*", s.deleteTicketClass},
+		{http.MethodGet, "events/*/ticket_groups", s.listTicketGroups},
+		{http.MethodGet, "events/*/attendees", s.listEventAttendees},
+		{http.MethodGet, "events/*/attendees/*", s.getEventAttendee},
+		{http.MethodGet, "events/*/orders", s.listEventOrders},
+
+		{http.MethodGet, "orders/*", s.getOrder},
+
+		{http.MethodGet, "users/*", s.getUser},
+		{http.MethodGet, "users/*/owned_events", s.listOwnedEvents},
+		{http.MethodGet, "users/*/events", s.listOwnedEvents},
+		{http.MethodGet, "users/*/orders", s.listOrders},
+		{http.MethodGet, "users/*/owned_event_orders", s.listOrders},
+		{http.MethodGet, "users/*/owned_event_attendees", s.listAttendees},
+		{http.MethodGet, "users/*/venues", s.listVenues},
+		{http.MethodGet, "users/*/organizers", s.listOrganizers},
+
+		{http.MethodPost, "venues", s.createVenue},
+		{http.MethodGet, "venues/*", s.getVenue},
+		{http.MethodPost, "venues/*", s.updateVenue},
+		{http.MethodGet, "venues/*/events", s.listVenueEvents},
+
+		{http.MethodPost, "organizers", s.createOrganizer},
+		{http.MethodGet, "organizers/*", s.getOrganizer},
+		{http.MethodPost, "organizers/*", s.updateOrganizer},
+		{http.MethodGet, "organizers/*/events", s.listOrganizerEvents},
+
+		{http.MethodGet, "webhooks", s.listWebhooks},
+		{http.MethodPost, "webhooks", s.createWebhook},
+		{http.MethodGet, "webhooks/*", s.getWebhook},
+		{http.MethodDelete, "webhooks/*", s.deleteWebhook},
+
+		{http.MethodPost, "discounts", s.createDiscount},
+		{http.MethodGet, "discounts/*", s.getDiscount},
+		{http.MethodPost, "discounts/*", s.updateDiscount},
+		{http.MethodDelete, "discounts/*", s.deleteDiscount},
+	}
+}
+
+// route dispatches the request to its handler and reports whether one was found
+func (s *Server) route(r *request) bool {
+	for _, rt := range s.routes() {
+		if params, ok := r.match(rt.method, strings.Split(rt.pattern, "/")...); ok {
+			rt.handle(r, params)
+			return true
+		}
+	}
+	return false
+}
+
+func (s *Server) page(r *request, key string, items interface{}, pagination eventbrite.Pagination) {
+	writeJSON(r.w, http.StatusOK, map[string]interface{}{
+		"pagination": pagination,
+		key:          items,
+	})
+}
+
+func listEvents(s *Server, r *request, filter func(*eventbrite.Event) bool) {
+	events := s.events.list(filter)
+	for i := range events {
+		events[i] = s.expandEvent(events[i])
+	}
+	items, p := paginate(r, s.PageSize, events)
+	s.page(r, "events", items, p)
+}
+
+// expandEvent fills the venue and organizer of the event, which the client expands by default
+func (s *Server) expandEvent(e eventbrite.Event) eventbrite.Event {
+	if v, ok := s.venues.get(e.VenueId); ok {
+		e.Venue = *v
+	}
+	if o, ok := s.organizers.get(e.OrganizerId); ok {
+		e.Organizer = *o
+	}
+	return e
+}
+
+// statusFilter matches the comma separated status query parameter, where "all" or no value
+// matches any status
+func statusFilter(r *request) func(status string) bool {
+	q := r.URL.Query().Get("status")
+	if q == "" || q == "all" {
+		return func(string) bool { return true }
+	}
+	allowed := strings.Split(q, ",")
+	return func(status string) bool {
+		for _, a := range allowed {
+			if a == status {
+				return true
+			}
+		}
+		return false
+	}
+}
+
+// changedSince matches objects changed on or after the changed_since query parameter
+func changedSince(r *request) func(eventbrite.DateTime) bool {
+	t, err := time.Parse("2006-01-02T15:04:05Z", r.URL.Query().Get("changed_since"))
+	if err != nil {
+		return func(eventbrite.DateTime) bool { return true }
+	}
+	return func(changed eventbrite.DateTime) bool {
+		return !changed.Time.Before(t)
+	}
+}
+
+func (s *Server) searchEvents(r *request, _ []string) {
+	q := strings.ToLower(r.URL.Query().Get("q"))
+	listEvents(s, r, func(e *eventbrite.Event) bool {
+		return e.Status == "live" && strings.Contains(strings.ToLower(e.Name.Text+" "+e.Name.Html), q)
+	})
+}
+
+func (s *Server) listOwnedEvents(r *request, _ []string) {
+	status := statusFilter(r)
+	listEvents(s, r, func(e *eventbrite.Event) bool {
+		return status(e.Status)
+	})
+}
+
+func (s *Server) listVenueEvents(r *request, params []string) {
+	if _, ok := s.venues.get(params[0]); !ok {
+		notFound(r.w)
+		return
+	}
+	status := statusFilter(r)
+	listEvents(s, r, func(e *eventbrite.Event) bool {
+		return e.VenueId == params[0] && status(e.Status)
+	})
+}
+
+func (s *Server) listOrganizerEvents(r *request, params []string) {
+	if _, ok := s.organizers.get(params[0]); !ok {
+		notFound(r.w)
+		return
+	}
+	status := statusFilter(r)
+	listEvents(s, r, func(e *eventbrite.Event) bool {
+		return e.OrganizerId == params[0] && status(e.Status)
+	})
+}
+
+func (s *Server) getEvent(r *request, params []string) {
+	e, ok := s.events.get(params[0])
+	if !ok {
+		notFound(r.w)
+		return
+	}
+	writeJSON(r.w, http.StatusOK, s.expandEvent(*e))
+}
+
+func (s *Server) createEvent(r *request, _ []string) {
+	missing := map[string][]string{}
+	for _, key := range []string{"event.name.html", "event.start.utc", "event.start.timezone",
+		"event.end.utc", "event.end.timezone", "event.currency"} {
+		if !r.body.has(key) {
+			missing[key] = []string{"MISSING"}
+		}
+	}
+	if len(missing) > 0 {
+		writeArgumentsError(r.w, missing)
+		return
+	}
+
+	e := &eventbrite.Event{Id: s.nextID(), Status: "draft", Created: now()}
+	if !s.applyEvent(r, e) {
+		return
+	}
+	s.events.put(e.Id, e)
+	writeJSON(r.w, http.StatusOK, s.expandEvent(*e))
+}
+
+func (s *Server) updateEvent(r *request, params []string) {
+	e, ok := s.events.get(params[0])
+	if !ok {
+		notFound(r.w)
+		return
+	}
+
+	updated := *e
+	if !s.applyEvent(r, &updated) {
+		return
+	}
+	*e = updated
+	writeJSON(r.w, http.StatusOK, s.expandEvent(*e))
+}
+
+// applyEvent copies the event fields of the request body to e. It answers with an error
+// and returns false when the fields are invalid.
+func (s *Server) applyEvent(r *request, e *eventbrite.Event) bool {
+	f := r.body
+	if f.has("event.name.html") {
+		e.Name = eventbrite.MultipartText{Html: f.str("event.name.html"), Text: stripTags(f.str("event.name.html"))}
+	}
+	if f.has("event.description.html") {
+		e.Description = eventbrite.MultipartText{Html: f.str("event.description.html"), Text: stripTags(f.str("event.description.html"))}
+	}
+
+	invalid := map[string][]string{}
+	for k, dt := range map[string]*eventbrite.DatetimeTz{"event.start": &e.Start, "event.end": &e.End} {
+		if !f.has(k+".utc") && !f.has(k+".timezone") {
+			continue
+		}
+
+		utc, tz := dt.Utc, dt.Timezone
+		if f.has(k + ".utc") {
+			utc = f.str(k + ".utc")
+		}
+		if f.has(k + ".timezone") {
+			tz = f.str(k + ".timezone")
+		}
+
+		v, err := datetimeTz(utc, tz)
+		if err != nil {
+			invalid[k+".utc"] = []string{"INVALID"}
+			continue
+		}
+		*dt = v
+	}
+	if len(invalid) > 0 {
+		writeArgumentsError(r.w, invalid)
+		return false
+	}
+
+	if f.has("event.currency") {
+		e.Currency = f.str("event.currency")
+	}
+	if f.has("event.online_event") {
+		e.OnlineEvent = f.boolean("event.online_event")
+	}
+	if f.has("event.venue_id") {
+		e.VenueId = f.str("event.venue_id")
+	}
+	if f.has("event.organizer_id") {
+		e.OrganizerId = f.str("event.organizer_id")
+	}
+	if f.has("event.category_id") {
+		e.CategoryId = f.str("event.category_id")
+	}
+	if f.has("event.subcategory_id") {
+		e.SubCategoryId = f.str("event.subcategory_id")
+	}
+	if f.has("event.format_id") {
+		e.FormatId = f.str("event.format_id")
+	}
+	if f.has("event.logo_id") {
+		e.LogoID = f.str("event.logo_id")
+	}
+
+	if e.OnlineEvent && e.VenueId != "" {
+		writeError(r.w, http.StatusBadRequest, "VENUE_AND_ONLINE",
+			"You cannot both specify a venue and set online_event")
+		return false
+	}
+	e.Changed = now()
+	return true
+}
+
+func (s *Server) deleteEvent(r *request, params []string) {
+	if !s.events.delete(params[0]) {
+		notFound(r.w)
+		return
+	}
+	writeJSON(r.w, http.StatusOK, map[string]bool{"deleted": true})
+}
+
+func (s *Server) setEventStatus(status, key string) func(r *request, params []string) {
+	return func(r *request, params []string) {
+		e, ok := s.events.get(params[0])
+		if !ok {
+			notFound(r.w)
+			return
+		}
+		e.Status = status
+		e.Changed = now()
+		writeJSON(r.w, http.StatusOK, map[string]bool{key: true})
+	}
+}
+
+func (s *Server) listTicketClasses(r *request, params []string) {
+	if _, ok := s.events.get(params[0]); !ok {
+		notFound(r.w)
+		return
+	}
+	items, p := paginate(r, s.PageSize, s.ticketClasses.list(func(tc *eventbrite.TicketClass) bool {
+		return tc.EventID == params[0]
+	}))
+	s.page(r, "ticket_classes", items, p)
+}
+
+func (s *Server) ticketClass(r *request, params []string) (*eventbrite.TicketClass, bool) {
+	tc, ok := s.ticketClasses.get(params[1])
+	if !ok || tc.EventID != params[0] {
+		notFound(r.w)
+		return nil, false
+	}
+	return tc, true
+}
+
+func (s *Server) getTicketClass(r *request, params []string) {
+	if tc, ok := s.ticketClass(r, params); ok {
+		writeJSON(r.w, http.StatusOK, tc)
+	}
+}
+
+func (s *Server) createTicketClass(r *request, params []string) {
+	if _, ok := s.events.get(params[0]); !ok {
+		notFound(r.w)
+		return
+	}
+	if !r.body.has("ticket_class.name") {
+		writeArgumentsError(r.w, map[string][]string{"ticket_class.name": {"MISSING"}})
+		return
+	}
+
+	tc := &eventbrite.TicketClass{ID: s.nextID(), EventID: params[0]}
+	applyTicketClass(r.body, tc)
+	s.ticketClasses.put(tc.ID, tc)
+	writeJSON(r.w, http.StatusOK, tc)
+}
+
+func (s *Server) updateTicketClass(r *request, params []string) {
+	if tc, ok := s.ticketClass(r, params); ok {
+		applyTicketClass(r.body, tc)
+		writeJSON(r.w, http.StatusOK, tc)
+	}
+}
+
+func (s *Server) deleteTicketClass(r *request, params []string) {
+	if _, ok := s.ticketClass(r, params); ok {
+		s.ticketClasses.delete(params[1])
+		writeJSON(r.w, http.StatusOK, map[string]bool{"deleted": true})
+	}
+}
+
+// listTicketGroups lists the groups holding a ticket class of the event, in the given status,
+// live by default
+func (s *Server) listTicketGroups(r *request, params []string) {
+	if _, ok := s.events.get(params[0]); !ok {
+		notFound(r.w)
+		return
+	}
+	status := r.URL.Query().Get("status")
+	if status == "" {
+		status = "live"
+	}
+
+	items, p := paginate(r, s.PageSize, s.ticketGroups.list(func(g *eventbrite.TicketGroup) bool {
+		if status != "all" && g.Status != status {
+			return false
+		}
+		ids, _ := g.EventTicketIds.(map[string]interface{})
+		_, ok := ids[params[0]]
+		return ok
+	}))
+	s.page(r, "ticket_groups", items, p)
+}
+
+func applyTicketClass(f form, tc *eventbrite.TicketClass) {
+	str := map[string]*string{
+		"ticket_class.name":              &tc.Name,
+		"ticket_class.description":       &tc.Description,
+		"ticket_class.sales_start":       &tc.SalesStart,
+		"ticket_class.sales_end":         &tc.SalesEnd,
+		"ticket_class.sales_start_after": &tc.SalesStartAfter,
+		"ticket_class.auto_hide_before":  &tc.AutoHideBefore,
+		"ticket_class.auto_hide_after":   &tc.AutoHideAfter,
+	}
+	for k, p := range str {
+		if f.has(k) {
+			*p = f.str(k)
+		}
+	}
+
+	ints := map[string]*int{
+		"ticket_class.quantity_total":   &tc.QuantityTotal,
+		"ticket_class.minimum_quantity": &tc.MinimumQuantity,
+		"ticket_class.maximum_quantity": &tc.MaximumQuantity,
+	}
+	for k, p := range ints {
+		if f.has(k) {
+			*p = f.integer(k)
+		}
+	}
+
+	bools := map[string]*bool{
+		"ticket_class.free":             &tc.Free,
+		"ticket_class.donation":         &tc.Donation,
+		"ticket_class.hidden":           &tc.Hidden,
+		"ticket_class.include_fee":      &tc.IncludeFee,
+		"ticket_class.split_fee":        &tc.SplitFee,
+		"ticket_class.hide_description": &tc.HideDescription,
+		"ticket_class.auto_hide":        &tc.AutoHide,
+	}
+	for k, p := range bools {
+		if f.has(k) {
+			*p = f.boolean(k)
+		}
+	}
+
+	// The cost is formatted as "USD,4500"
+	if parts := strings.SplitN(f.str("ticket_class.cost"), ",", 2); len(parts) == 2 {
+		value, _ := strconv.ParseFloat(parts[1], 32)
+		tc.Cost = eventbrite.Currency{
+			Currency: eventbrite.CurrencyCode(parts[0]),
+			Value:    float32(value),
+			Display:  strconv.FormatFloat(value/100, 'f', 2, 64) + " " + parts[0],
+		}
+	}
+}
+
+func (s *Server) listEventAttendees(r *request, params []string) {
+	if _, ok := s.events.get(params[0]); !ok {
+		notFound(r.w)
+		return
+	}
+	s.writeAttendees(r, func(a *eventbrite.Attendee) bool {
+		return a.EventID == params[0]
+	})
+}
+
+func (s *Server) listAttendees(r *request, _ []string) {
+	s.writeAttendees(r, nil)
+}
+
+func (s *Server) writeAttendees(r *request, filter func(*eventbrite.Attendee) bool) {
+	status := r.URL.Query().Get("status")
+	changed := changedSince(r)
+
+	items, p := paginate(r, s.PageSize, s.attendees.list(func(a *eventbrite.Attendee) bool {
+		attending := !a.Cancelled && !a.Refunded
+		switch {
+		case filter != nil && !filter(a):
+			return false
+		case status == "attending" && !attending, status == "not_attending" && attending:
+			return false
+		}
+		return changed(a.Changed)
+	}))
+	s.page(r, "attendees", items, p)
+}
+
+func (s *Server) getEventAttendee(r *request, params []string) {
+	a, ok := s.attendees.get(params[1])
+	if !ok || a.EventID != params[0] {
+		notFound(r.w)
+		return
+	}
+	writeJSON(r.w, http.StatusOK, a)
+}
+
+func (s *Server) listEventOrders(r *request, params []string) {
+	if _, ok := s.events.get(params[0]); !ok {
+		notFound(r.w)
+		return
+	}
+	s.writeOrders(r, func(o *eventbrite.Order) bool {
+		return o.EventID == params[0]
+	})
+}
+
+func (s *Server) listOrders(r *request, _ []string) {
+	s.writeOrders(r, nil)
+}
+
+func (s *Server) writeOrders(r *request, filter func(*eventbrite.Order) bool) {
+	changed := changedSince(r)
+	items, p := paginate(r, s.PageSize, s.orders.list(func(o *eventbrite.Order) bool {
+		return (filter == nil || filter(o)) && changed(o.Changed)
+	}))
+	s.page(r, "orders", items, p)
+}
+
+func (s *Server) getOrder(r *request, params []string) {
+	o, ok := s.orders.get(params[0])
+	if !ok {
+		notFound(r.w)
+		return
+	}
+	writeJSON(r.w, http.StatusOK, o)
+}
+
+func (s *Server) getUser(r *request, params []string) {
+	if params[0] != "me" && params[0] != s.User.ID {
+		notFound(r.w)
+		return
+	}
+	writeJSON(r.w, http.StatusOK, s.User)
+}
+
+func (s *Server) listVenues(r *request, _ []string) {
+	items, p := paginate(r, s.PageSize, s.venues.list(nil))
+	s.page(r, "venues", items, p)
+}
+
+func (s *Server) getVenue(r *request, params []string) {
+	v, ok := s.venues.get(params[0])
+	if !ok {
+		notFound(r.w)
+		return
+	}
+	writeJSON(r.w, http.StatusOK, v)
+}
+
+func (s *Server) createVenue(r *request, _ []string) {
+	if !r.body.has("venue.name") {
+		writeArgumentsError(r.w, map[string][]string{"venue.name": {"MISSING"}})
+		return
+	}
+
+	v := &eventbrite.Venue{ID: s.nextID()}
+	applyVenue(r.body, v)
+	s.venues.put(v.ID, v)
+	writeJSON(r.w, http.StatusOK, v)
+}
+
+func (s *Server) updateVenue(r *request, params []string) {
+	v, ok := s.venues.get(params[0])
+	if !ok {
+		notFound(r.w)
+		return
+	}
+	applyVenue(r.body, v)
+	writeJSON(r.w, http.StatusOK, v)
+}
+
+func applyVenue(f form, v *eventbrite.Venue) {
+	fields := map[string]*string{
+		"venue.name":                &v.Name,
+		"venue.address.address_1":   &v.Address.Address1,
+		"venue.address.address_2":   &v.Address.Address2,
+		"venue.address.city":        &v.Address.City,
+		"venue.address.region":      &v.Address.Region,
+		"venue.address.postal_code": &v.Address.PostalCode,
+		"venue.address.country":     &v.Address.Country,
+		"venue.address.latitude":    &v.Address.Latitude,
+		"venue.address.longitude":   &v.Address.Longitude,
+	}
+	for k, p := range fields {
+		if f.has(k) {
+			*p = f.str(k)
+		}
+	}
+}
+
+func (s *Server) listOrganizers(r *request, _ []string) {
+	items, p := paginate(r, s.PageSize, s.organizers.list(nil))
+	s.page(r, "organizers", items, p)
+}
+
+func (s *Server) getOrganizer(r *request, params []string) {
+	o, ok := s.organizers.get(params[0])
+	if !ok {
+		notFound(r.w)
+		return
+	}
+	writeJSON(r.w, http.StatusOK, o)
+}
+
+func (s *Server) createOrganizer(r *request, _ []string) {
+	if !r.body.has("organizer.name") {
+		writeArgumentsError(r.w, map[string][]string{"organizer.name": {"MISSING"}})
+		return
+	}
+
+	o := &eventbrite.Organizer{ID: s.nextID()}
+	applyOrganizer(r.body, o)
+	s.organizers.put(o.ID, o)
+	writeJSON(r.w, http.StatusOK, o)
+}
+
+func (s *Server) updateOrganizer(r *request, params []string) {
+	o, ok := s.organizers.get(params[0])
+	if !ok {
+		notFound(r.w)
+		return
+	}
+	applyOrganizer(r.body, o)
+	writeJSON(r.w, http.StatusOK, o)
+}
+
+func applyOrganizer(f form, o *eventbrite.Organizer) {
+	if f.has("organizer.name") {
+		o.Name = f.str("organizer.name")
+	}
+	if f.has("organizer.description.html") {
+		html := f.str("organizer.description.html")
+		o.Description = eventbrite.MultipartText{Html: html, Text: stripTags(html)}
+	}
+}
+
+func (s *Server) listWebhooks(r *request, _ []string) {
+	items, p := paginate(r, s.PageSize, s.webhooks.list(nil))
+	s.page(r, "webhooks", items, p)
+}
+
+func (s *Server) getWebhook(r *request, params []string) {
+	wh, ok := s.webhooks.get(params[0])
+	if !ok {
+		notFound(r.w)
+		return
+	}
+	writeJSON(r.w, http.StatusOK, wh)
+}
+
+func (s *Server) createWebhook(r *request, _ []string) {
+	if !r.body.has("endpoint_url") {
+		writeArgumentsError(r.w, map[string][]string{"endpoint_url": {"MISSING"}})
+		return
+	}
+
+	wh := &eventbrite.Webhook{
+		ID:          s.nextID(),
+		EndpointUrl: r.body.str("endpoint_url"),
+		Actions:     r.body.str("actions"),
+		EventID:     r.body.str("event_id"),
+	}
+	if wh.Actions == "" {
+		wh.Actions = "order.placed,event.published,event.unpublished"
+	}
+	s.webhooks.put(wh.ID, wh)
+	writeJSON(r.w, http.StatusOK, wh)
+}
+
+func (s *Server) deleteWebhook(r *request, params []string) {
+	if !s.webhooks.delete(params[0]) {
+		notFound(r.w)
+		return
+	}
+	writeJSON(r.w, http.StatusOK, map[string]bool{"deleted": true})
+}
+
+func (s *Server) getDiscount(r *request, params []string) {
+	d, ok := s.discounts.get(params[0])
+	if !ok {
+		notFound(r.w)
+		return
+	}
+	writeJSON(r.w, http.StatusOK, d)
+}
+
+func (s *Server) createDiscount(r *request, _ []string) {
+	if !r.body.has("discount.code") {
+		writeArgumentsError(r.w, map[string][]string{"discount.code": {"MISSING"}})
+		return
+	}
+
+	d := &eventbrite.CrossEventDiscount{ID: s.nextID(), Type: "coded"}
+	applyDiscount(r.body, d)
+	s.discounts.put(d.ID, d)
+	writeJSON(r.w, http.StatusOK, d)
+}
+
+func (s *Server) updateDiscount(r *request, params []string) {
+	d, ok := s.discounts.get(params[0])
+	if !ok {
+		notFound(r.w)
+		return
+	}
+	applyDiscount(r.body, d)
+	writeJSON(r.w, http.StatusOK, d)
+}
+
+func (s *Server) deleteDiscount(r *request, params []string) {
+	d, ok := s.discounts.get(params[0])
+	if !ok {
+		notFound(r.w)
+		return
+	}
+	if d.QuantitySold > 0 {
+		writeError(r.w, http.StatusBadRequest, "DISCOUNT_CANNOT_BE_DELETED", "Only unused discounts can be deleted.")
+		return
+	}
+	s.discounts.delete(params[0])
+	writeJSON(r.w, http.StatusOK, map[string]bool{"deleted": true})
+}
+
+func applyDiscount(f form, d *eventbrite.CrossEventDiscount) {
+	if f.has("discount.code") {
+		d.Code = f.str("discount.code")
+	}
+	if f.has("discount.type") {
+		d.Type = f.str("discount.type")
+	}
+	if f.has("discount.amount_off") {
+		d.AmountOff = f.number("discount.amount_off")
+	}
+	if f.has("discount.percent_off") {
+		d.PercentOff = f.number("discount.percent_off")
+	}
+	if f.has("discount.quantity_available") {
+		d.QuantityAvailable = f.integer("discount.quantity_available")
+	}
+	if f.has("discount.event_id") {
+		d.EventID = f.str("discount.event_id")
+	}
+	if f.has("discount.ticket_group_id") {
+		d.TicketGroupID = f.str("discount.ticket_group_id")
+	}
+	if f.has("discount.ticket_class_ids") {
+		d.TicketClassIds = f.strings("discount.ticket_class_ids")
+	}
+	if f.has("discount.hold_ids") {
+		d.HoldIds = f.strings("discount.hold_ids")
+	}
+}
+
+// datetimeTz builds the datetime of an event from its UTC time and timezone
+func datetimeTz(utc, timezone string) (eventbrite.DatetimeTz, error) {
+	t, err := time.Parse("2006-01-02T15:04:05Z", utc)
+	if err != nil {
+		return eventbrite.DatetimeTz{}, err
+	}
+
+	local := t
+	if loc, err := time.LoadLocation(timezone); err == nil {
+		local = t.In(loc)
+	}
+	return eventbrite.DatetimeTz{
+		Timezone: timezone,
+		Utc:      t.Format("2006-01-02T15:04:05Z"),
+		Local:    local.Format("2006-01-02T15:04:05"),
+	}, nil
+}
+
+// stripTags returns the text of an html snippet, as the API does for multipart text
+func stripTags(html string) string {
+	var b strings.Builder
+	inTag := false
+	for _, r := range html {
+		switch {
+		case r == '<':
+			inTag = true
+		case r == '>':
+			inTag = false
+		case !inTag:
+			b.WriteRune(r)
+		}
+	}
+	return b.String()
+}
@@ -0,0 +1,245 @@
+// Package eventbritetest provides an in-process fake of the Eventbrite API for tests.
+//
+// The fake server implements the v3 endpoints wrapped by the eventbrite Client on top of an
+// in-memory store, checks the token of every request, paginates list responses and answers
+// with the error JSON shape of the real API.
+//
+//	srv := eventbritetest.NewServer("secret")
+//	defer srv.Close()
+//
+//	id := srv.AddEvent(eventbrite.Event{Name: eventbrite.MultipartText{Text: "Party!"}})
+//	clnt, _ := srv.Client()
+//	e, err := clnt.EventGet(ctx, id)
+package eventbritetest
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/apzuk3/go-eventbrite"
+)
+
+// DefaultPageSize is the number of objects per page of list responses
+const DefaultPageSize = 50
+
+// Server is a fake Eventbrite API server backed by an in-memory store
+type Server struct {
+	*httptest.Server
+
+	// Token is the only token accepted by the server
+	Token string
+	// PageSize is the number of objects per page of list responses
+	PageSize int
+	// User is returned by the /users/:id/ endpoint
+	User eventbrite.User
+
+	mu            sync.Mutex
+	seq           int
+	events        *table[eventbrite.Event]
+	ticketClasses *table[eventbrite.TicketClass]
+	ticketGroups  *table[eventbrite.TicketGroup]
+	orders        *table[eventbrite.Order]
+	attendees     *table[eventbrite.Attendee]
+	venues        *table[eventbrite.Venue]
+	organizers    *table[eventbrite.Organizer]
+	webhooks      *table[eventbrite.Webhook]
+	discounts     *table[eventbrite.CrossEventDiscount]
+}
+
+// NewServer starts a fake Eventbrite API server accepting the given token. The caller should
+// call Close when finished, to shut it down.
+func NewServer(token string) *Server {
+	s := &Server{
+		Token:         token,
+		PageSize:      DefaultPageSize,
+		User:          eventbrite.User{ID: "1", Name: "Test User"},
+		seq:           1000,
+		events:        newTable[eventbrite.Event](),
+		ticketClasses: newTable[eventbrite.TicketClass](),
+		ticketGroups:  newTable[eventbrite.TicketGroup](),
+		orders:        newTable[eventbrite.Order](),
+		attendees:     newTable[eventbrite.Attendee](),
+		venues:        newTable[eventbrite.Venue](),
+		organizers:    newTable[eventbrite.Organizer](),
+		webhooks:      newTable[eventbrite.Webhook](),
+		discounts:     newTable[eventbrite.CrossEventDiscount](),
+	}
+	s.Server = httptest.NewServer(http.HandlerFunc(s.serveHTTP))
+	return s
+}
+
+// BaseURL returns the url to configure the client with through eventbrite.WithBaseURL
+func (s *Server) BaseURL() string {
+	return s.URL + "/v3"
+}
+
+// Client returns an eventbrite Client talking to the server with its token and no rate
+// limit. Additional options are applied after those.
+func (s *Server) Client(options ...eventbrite.ClientOption) (*eventbrite.Client, error) {
+	return eventbrite.NewClient(append([]eventbrite.ClientOption{
+		eventbrite.WithBaseURL(s.BaseURL()),
+		eventbrite.WithToken(s.Token),
+		eventbrite.WithRateLimit(0),
+	}, options...)...)
+}
+
+func (s *Server) serveHTTP(w http.ResponseWriter, r *http.Request) {
+	if !strings.HasPrefix(r.URL.Path, "/v3/") {
+		notFound(w)
+		return
+	}
+	if !s.authorized(w, r) {
+		return
+	}
+
+	var segments []string
+	for _, seg := range strings.Split(strings.TrimPrefix(r.URL.Path, "/v3/"), "/") {
+		if seg != "" {
+			segments = append(segments, seg)
+		}
+	}
+
+	body, err := readBody(r)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "BAD_REQUEST", "The request body is not valid JSON.")
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	req := &request{
+		Request:  r,
+		w:        w,
+		segments: segments,
+		body:     body,
+	}
+	if !s.route(req) {
+		notFound(w)
+	}
+}
+
+// authorized checks the token passed either in the query or as a bearer token
+func (s *Server) authorized(w http.ResponseWriter, r *http.Request) bool {
+	token := r.URL.Query().Get("token")
+	if h := r.Header.Get("Authorization"); strings.HasPrefix(h, "Bearer ") {
+		token = strings.TrimPrefix(h, "Bearer ")
+	}
+
+	switch token {
+	case "":
+		writeError(w, http.StatusUnauthorized, "NO_AUTH", "An OAuth token is required for all requests.")
+		return false
+	case s.Token:
+		return true
+	}
+	writeError(w, http.StatusUnauthorized, "INVALID_AUTH", "The OAuth token you provided was invalid.")
+	return false
+}
+
+// request is an incoming API request with its path split into segments
+type request struct {
+	*http.Request
+	w        http.ResponseWriter
+	segments []string
+	body     form
+}
+
+// match reports whether the request has the given method and path, where "*" matches any
+// segment. Matched wildcard segments are returned in order.
+func (r *request) match(method string, pattern ...string) ([]string, bool) {
+	if r.Method != method || len(pattern) != len(r.segments) {
+		return nil, false
+	}
+
+	var params []string
+	for i, p := range pattern {
+		switch p {
+		case "*":
+			params = append(params, r.segments[i])
+		case r.segments[i]:
+		default:
+			return nil, false
+		}
+	}
+	return params, true
+}
+
+func writeJSON(w http.ResponseWriter, status int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(v)
+}
+
+// writeError answers with the error format of the API
+//
+// https://www.eventbrite.com/developer/v3/api_overview/errors/
+func writeError(w http.ResponseWriter, status int, key, description string) {
+	writeJSON(w, status, map[string]interface{}{
+		"error":             key,
+		"error_description": description,
+		"status_code":       status,
+	})
+}
+
+// writeArgumentsError answers with an ARGUMENTS_ERROR listing the invalid arguments
+func writeArgumentsError(w http.ResponseWriter, args map[string][]string) {
+	writeJSON(w, http.StatusBadRequest, map[string]interface{}{
+		"error":             "ARGUMENTS_ERROR",
+		"error_description": "There are errors with your arguments.",
+		"status_code":       http.StatusBadRequest,
+		"error_detail": map[string]interface{}{
+			"ARGUMENTS_ERROR": args,
+		},
+	})
+}
+
+func notFound(w http.ResponseWriter) {
+	writeError(w, http.StatusNotFound, "NOT_FOUND", "The path you requested does not exist.")
+}
+
+// paginate returns the page of items requested either by the page or the continuation
+// parameter, along with the matching pagination
+func paginate[T any](r *request, size int, items []T) ([]T, eventbrite.Pagination) {
+	page, _ := strconv.Atoi(r.URL.Query().Get("page"))
+	if c := r.URL.Query().Get("continuation"); c != "" {
+		if b, err := base64.RawURLEncoding.DecodeString(c); err == nil {
+			page, _ = strconv.Atoi(strings.TrimPrefix(string(b), "page:"))
+		}
+	}
+	if page < 1 {
+		page = 1
+	}
+	if size < 1 {
+		size = DefaultPageSize
+	}
+
+	p := eventbrite.Pagination{
+		ObjectCount: len(items),
+		PageNumber:  page,
+		PageSize:    size,
+		PageCount:   (len(items) + size - 1) / size,
+	}
+	if p.PageCount == 0 {
+		p.PageCount = 1
+	}
+	if page < p.PageCount {
+		p.HasMoreItems = true
+		p.Continuation = base64.RawURLEncoding.EncodeToString([]byte("page:" + strconv.Itoa(page+1)))
+	}
+
+	start := (page - 1) * size
+	if start > len(items) {
+		start = len(items)
+	}
+	end := start + size
+	if end > len(items) {
+		end = len(items)
+	}
+	return items[start:end], p
+}
@@ -0,0 +1,111 @@
+package eventbritetest
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/apzuk3/go-eventbrite"
+
+	"golang.org/x/net/context"
+)
+
+func TestServerAuth(t *testing.T) {
+	srv := NewServer("secret")
+	defer srv.Close()
+	id := srv.AddEvent(eventbrite.Event{})
+
+	tests := []struct {
+		name    string
+		options []eventbrite.ClientOption
+		wantErr error
+	}{
+		{"query token", nil, nil},
+		{"invalid token", []eventbrite.ClientOption{eventbrite.WithToken("wrong")}, eventbrite.ErrInvalidAuth},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			clnt, err := srv.Client(tt.options...)
+			if err != nil {
+				t.Fatal(err)
+			}
+			_, err = clnt.EventGet(context.Background(), id)
+			if tt.wantErr == nil && err != nil || tt.wantErr != nil && !errors.Is(err, tt.wantErr) {
+				t.Errorf("EventGet() error = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestServerEvents(t *testing.T) {
+	srv := NewServer("secret")
+	defer srv.Close()
+	srv.PageSize = 2
+
+	venue := srv.AddVenue(eventbrite.Venue{Name: "Hall"})
+	for _, status := range []string{"live", "draft", "live", "live"} {
+		srv.AddEvent(eventbrite.Event{Status: status, VenueId: venue})
+	}
+
+	clnt, err := srv.Client()
+	if err != nil {
+		t.Fatal(err)
+	}
+	ctx := context.Background()
+
+	t.Run("list", func(t *testing.T) {
+		var names []string
+		err := clnt.UserOwnedEventsIterator(ctx, "me", &eventbrite.UserOwnedEventsRequest{Status: "live"}).Walk(func(e eventbrite.Event) error {
+			if e.Venue.Name != "Hall" {
+				t.Errorf("event %s venue = %q, want the expanded Hall", e.Id, e.Venue.Name)
+			}
+			names = append(names, e.Status)
+			return nil
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got := strings.Join(names, ","); got != "live,live,live" {
+			t.Errorf("statuses = %s, want the 3 live events over 2 pages", got)
+		}
+	})
+
+	t.Run("create", func(t *testing.T) {
+		start := time.Date(2026, 7, 1, 18, 0, 0, 0, time.UTC)
+		e, err := clnt.EventCreate(ctx, &eventbrite.EventCreateRequest{Event: eventbrite.EventFields{
+			Name:     &eventbrite.HTMLText{HTML: "<p>Party!</p>"},
+			Start:    &eventbrite.EventTime{Utc: eventbrite.DateTime{Time: start}, Timezone: "Europe/London"},
+			End:      &eventbrite.EventTime{Utc: eventbrite.DateTime{Time: start.Add(3 * time.Hour)}, Timezone: "Europe/London"},
+			Currency: "GBP",
+		}})
+		if err != nil {
+			t.Fatal(err)
+		}
+		stored, ok := srv.Event(e.Id)
+		if !ok || stored.Name.Text != "Party!" || stored.Status != "draft" {
+			t.Errorf("stored event = %+v, want a draft named Party!", stored)
+		}
+	})
+
+	t.Run("update", func(t *testing.T) {
+		id := srv.AddEvent(eventbrite.Event{Currency: "USD"})
+		e, err := clnt.EventUpdate(ctx, id, &eventbrite.EventUpdateRequest{Event: eventbrite.EventFields{Currency: "EUR"}})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if e.Currency != "EUR" {
+			t.Errorf("currency = %q, want EUR", e.Currency)
+		}
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		_, err := clnt.EventGet(ctx, "404")
+		var apiErr *eventbrite.Error
+		if !errors.As(err, &apiErr) || !errors.Is(err, eventbrite.ErrNotFound) || apiErr.Path != "/events/404" {
+			t.Errorf("EventGet() error = %#v, want NOT_FOUND on /events/404", err)
+		}
+	})
+
+}
@@ -0,0 +1,250 @@
+package eventbritetest
+
+import (
+	"strconv"
+	"time"
+
+	"github.com/apzuk3/go-eventbrite"
+)
+
+// table is an in-memory collection keeping objects in insertion order
+type table[T any] struct {
+	ids  []string
+	rows map[string]*T
+}
+
+func newTable[T any]() *table[T] {
+	return &table[T]{rows: map[string]*T{}}
+}
+
+func (t *table[T]) put(id string, v *T) {
+	if _, ok := t.rows[id]; !ok {
+		t.ids = append(t.ids, id)
+	}
+	t.rows[id] = v
+}
+
+func (t *table[T]) get(id string) (*T, bool) {
+	v, ok := t.rows[id]
+	return v, ok
+}
+
+func (t *table[T]) delete(id string) bool {
+	if _, ok := t.rows[id]; !ok {
+		return false
+	}
+	delete(t.rows, id)
+	for i, v := range t.ids {
+		if v == id {
+			t.ids = append(t.ids[:i], t.ids[i+1:]...)
+			break
+		}
+	}
+	return true
+}
+
+// list returns the objects accepted by filter, a nil filter accepts all of them
+func (t *table[T]) list(filter func(*T) bool) []T {
+	res := []T{}
+	for _, id := range t.ids {
+		if v := t.rows[id]; filter == nil || filter(v) {
+			res = append(res, *v)
+		}
+	}
+	return res
+}
+
+func (s *Server) nextID() string {
+	s.seq++
+	return strconv.Itoa(s.seq)
+}
+
+func now() eventbrite.DateTime {
+	return eventbrite.DateTime{Time: time.Now().UTC().Truncate(time.Second)}
+}
+
+// AddEvent stores an event and returns its ID. An ID is generated when missing, and events
+// without status are live so that they show up in search results.
+func (s *Server) AddEvent(e eventbrite.Event) string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if e.Id == "" {
+		e.Id = s.nextID()
+	}
+	if e.Status == "" {
+		e.Status = "live"
+	}
+	if e.Created.Time.IsZero() {
+		e.Created = now()
+	}
+	if e.Changed.Time.IsZero() {
+		e.Changed = e.Created
+	}
+	s.events.put(e.Id, &e)
+	return e.Id
+}
+
+// AddTicketClass stores a ticket class of the given event and returns its ID
+func (s *Server) AddTicketClass(eventID string, tc eventbrite.TicketClass) string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if tc.ID == "" {
+		tc.ID = s.nextID()
+	}
+	tc.EventID = eventID
+	s.ticketClasses.put(tc.ID, &tc)
+	return tc.ID
+}
+
+// AddTicketGroup stores a ticket group and returns its ID. Groups without status are live.
+func (s *Server) AddTicketGroup(g eventbrite.TicketGroup) string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if g.ID == "" {
+		g.ID = s.nextID()
+	}
+	if g.Status == "" {
+		g.Status = "live"
+	}
+	s.ticketGroups.put(g.ID, &g)
+	return g.ID
+}
+
+// AddOrder stores an order and returns its ID
+func (s *Server) AddOrder(o eventbrite.Order) string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if o.ID == "" {
+		o.ID = s.nextID()
+	}
+	if o.Created.Time.IsZero() {
+		o.Created = now()
+	}
+	if o.Changed.Time.IsZero() {
+		o.Changed = o.Created
+	}
+	s.orders.put(o.ID, &o)
+	return o.ID
+}
+
+// AddAttendee stores an attendee and returns its ID
+func (s *Server) AddAttendee(a eventbrite.Attendee) string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if a.ID == "" {
+		a.ID = s.nextID()
+	}
+	if a.Created.Time.IsZero() {
+		a.Created = now()
+	}
+	if a.Changed.Time.IsZero() {
+		a.Changed = a.Created
+	}
+	s.attendees.put(a.ID, &a)
+	return a.ID
+}
+
+// AddVenue stores a venue and returns its ID
+func (s *Server) AddVenue(v eventbrite.Venue) string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if v.ID == "" {
+		v.ID = s.nextID()
+	}
+	s.venues.put(v.ID, &v)
+	return v.ID
+}
+
+// AddOrganizer stores an organizer and returns its ID
+func (s *Server) AddOrganizer(o eventbrite.Organizer) string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if o.ID == "" {
+		o.ID = s.nextID()
+	}
+	s.organizers.put(o.ID, &o)
+	return o.ID
+}
+
+// AddWebhook stores a webhook and returns its ID
+func (s *Server) AddWebhook(wh eventbrite.Webhook) string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if wh.ID == "" {
+		wh.ID = s.nextID()
+	}
+	s.webhooks.put(wh.ID, &wh)
+	return wh.ID
+}
+
+// AddDiscount stores a discount and returns its ID
+func (s *Server) AddDiscount(d eventbrite.CrossEventDiscount) string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if d.ID == "" {
+		d.ID = s.nextID()
+	}
+	s.discounts.put(d.ID, &d)
+	return d.ID
+}
+
+// Event returns the stored event with the given ID
+func (s *Server) Event(id string) (eventbrite.Event, bool) {
+	return lookup(s, s.events, id)
+}
+
+// TicketClass returns the stored ticket class with the given ID
+func (s *Server) TicketClass(id string) (eventbrite.TicketClass, bool) {
+	return lookup(s, s.ticketClasses, id)
+}
+
+// Order returns the stored order with the given ID
+func (s *Server) Order(id string) (eventbrite.Order, bool) {
+	return lookup(s, s.orders, id)
+}
+
+// Attendee returns the stored attendee with the given ID
+func (s *Server) Attendee(id string) (eventbrite.Attendee, bool) {
+	return lookup(s, s.attendees, id)
+}
+
+// Venue returns the stored venue with the given ID
+func (s *Server) Venue(id string) (eventbrite.Venue, bool) {
+	return lookup(s, s.venues, id)
+}
+
+// Organizer returns the stored organizer with the given ID
+func (s *Server) Organizer(id string) (eventbrite.Organizer, bool) {
+	return lookup(s, s.organizers, id)
+}
+
+// Webhook returns the stored webhook with the given ID
+func (s *Server) Webhook(id string) (eventbrite.Webhook, bool) {
+	return lookup(s, s.webhooks, id)
+}
+
+// Discount returns the stored discount with the given ID
+func (s *Server) Discount(id string) (eventbrite.CrossEventDiscount, bool) {
+	return lookup(s, s.discounts, id)
+}
+
+func lookup[T any](s *Server, t *table[T], id string) (T, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var zero T
+	v, ok := t.get(id)
+	if !ok {
+		return zero, false
+	}
+	return *v, true
+}
@@ -0,0 +1,162 @@
+package eventbrite
+
+import (
+	"net/http"
+	"reflect"
+	"strings"
+	"sync"
+
+	"golang.org/x/net/context"
+)
+
+// Expansion is the name of a related object the API can embed into a response, instead of
+// only returning its ID
+//
+// https://www.eventbrite.com/developer/v3/api_overview/expansions/
+type Expansion string
+
+// Expansions supported by the objects of the API
+const (
+	ExpandAttendees          Expansion = "attendees"
+	ExpandBookmarkInfo       Expansion = "bookmark_info"
+	ExpandCategory           Expansion = "category"
+	ExpandEvent              Expansion = "event"
+	ExpandFormat             Expansion = "format"
+	ExpandLogo               Expansion = "logo"
+	ExpandOrder              Expansion = "order"
+	ExpandOrganizer          Expansion = "organizer"
+	ExpandPromotionalCode    Expansion = "promotional_code"
+	ExpandRefundPolicy       Expansion = "refund_policy"
+	ExpandRefundRequests     Expansion = "refund_requests"
+	ExpandSubcategory        Expansion = "subcategory"
+	ExpandSubcategories      Expansion = "subcategories"
+	ExpandTicketAvailability Expansion = "ticket_availability"
+	ExpandTicketClasses      Expansion = "ticket_classes"
+	ExpandVenue              Expansion = "venue"
+)
+
+// DefaultExpand is the set of expansions requested by a client configured without WithExpand
+var DefaultExpand = Expand{ExpandVenue, ExpandCategory, ExpandSubcategories}
+
+// Expand is a set of expansions
+type Expand []Expansion
+
+// String returns the expansions as the comma separated list expected by the expand parameter,
+// without duplicates
+func (e Expand) String() string {
+	seen := map[Expansion]bool{}
+	names := make([]string, 0, len(e))
+	for _, exp := range e {
+		if exp == "" || seen[exp] {
+			continue
+		}
+		seen[exp] = true
+		names = append(names, string(exp))
+	}
+	return strings.Join(names, ",")
+}
+
+// WithExpand configures the expansions requested by default on every GET and POST request of a
+// Eventbrite API client, among those the response of the request has a field for. No expansion
+// is requested when called without any.
+func WithExpand(expand ...Expansion) ClientOption {
+	return func(c *Client) error {
+		c.expand = Expand(expand)
+		return nil
+	}
+}
+
+type expandKey struct{}
+
+// ContextWithExpand returns a context whose requests use the given expansions instead of the
+// client default ones. No expansion is requested when called without any.
+//
+//	ctx = eventbrite.ContextWithExpand(ctx, eventbrite.ExpandTicketClasses, eventbrite.ExpandOrganizer)
+//	e, err := clnt.EventGet(ctx, "123")
+func ContextWithExpand(ctx context.Context, expand ...Expansion) context.Context {
+	return context.WithValue(ctx, expandKey{}, Expand(expand))
+}
+
+// expandFor returns the expansions of a request decoded into result, DELETE requests never
+// expand anything
+func (c *Client) expandFor(ctx context.Context, method string, result interface{}) Expand {
+	if method == http.MethodDelete {
+		return nil
+	}
+	expand, ok := ctx.Value(expandKey{}).(Expand)
+	if !ok {
+		expand = c.expand
+	}
+	return expand.decodable(result)
+}
+
+// decodable returns the expansions result has a field for, so that no expansion is requested
+// only to be dropped by the decoder. Fields are looked up on the object of the response, or on
+// the items of a list response, and dotted expansions like event.venue on the nested objects.
+// A result whose type tells nothing, like a nil interface{}, keeps them all.
+func (e Expand) decodable(result interface{}) Expand {
+	if len(e) == 0 || result == nil {
+		return e
+	}
+
+	typ := reflect.TypeOf(result)
+	res := make(Expand, 0, len(e))
+	for _, exp := range e {
+		if decodes(typ, exp) {
+			res = append(res, exp)
+		}
+	}
+	return res
+}
+
+type decodesKey struct {
+	typ reflect.Type
+	exp Expansion
+}
+
+// decodesCache maps a decodesKey to the result of decodes
+var decodesCache sync.Map
+
+// decodes reports whether values of typ have a field for the expansion
+func decodes(typ reflect.Type, exp Expansion) bool {
+	key := decodesKey{typ, exp}
+	if ok, found := decodesCache.Load(key); found {
+		return ok.(bool)
+	}
+
+	ok := hasField(typ, strings.Split(string(exp), "."), true)
+	decodesCache.Store(key, ok)
+	return ok
+}
+
+// hasField reports whether typ has a field at path. The items of the slice fields of a list
+// response are looked up as well. Untyped values may hold anything.
+func hasField(typ reflect.Type, path []string, list bool) bool {
+	for typ.Kind() == reflect.Ptr || typ.Kind() == reflect.Slice || typ.Kind() == reflect.Array {
+		typ = typ.Elem()
+	}
+	switch typ.Kind() {
+	case reflect.Interface, reflect.Map:
+		return true
+	case reflect.Struct:
+	default:
+		return false
+	}
+
+	for i := 0; i < typ.NumField(); i++ {
+		f := typ.Field(i)
+		if strings.Split(f.Tag.Get("json"), ",")[0] == path[0] {
+			return len(path) == 1 || hasField(f.Type, path[1:], false)
+		}
+	}
+	if !list {
+		return false
+	}
+	for i := 0; i < typ.NumField(); i++ {
+		f := typ.Field(i)
+		if f.Type.Kind() == reflect.Slice && f.Type.Elem().Kind() == reflect.Struct && hasField(f.Type.Elem(), path, false) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,84 @@
+package eventbrite
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"golang.org/x/net/context"
+)
+
+func TestExpandDecodable(t *testing.T) {
+	var anything interface{}
+
+	tests := []struct {
+		name   string
+		expand Expand
+		result interface{}
+		want   string
+	}{
+		{"event", Expand{ExpandVenue, ExpandOrganizer, ExpandTicketClasses}, &Event{}, "venue,organizer,ticket_classes"},
+		{"pointer to pointer", Expand{ExpandOrganizer}, new(*Event), "organizer"},
+		{"order", Expand{ExpandAttendees, ExpandRefundRequests, ExpandEvent}, &Order{}, "attendees,refund_requests,event"},
+		{"list", Expand{ExpandEvent, ExpandOrder, ExpandPromotionalCode}, &EventAttendeesResult{}, "event,order,promotional_code"},
+		{"dropped", Expand{ExpandVenue, ExpandAttendees}, &Webhook{}, ""},
+		{"dotted", Expand{"event.venue", "venue.address"}, &Attendee{}, "event.venue"},
+		{"untyped", Expand{ExpandVenue}, anything, "venue"},
+		{"map", Expand{ExpandVenue}, &map[string]interface{}{}, "venue"},
+		{"none", nil, &Event{}, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.expand.decodable(tt.result).String(); got != tt.want {
+				t.Errorf("decodable() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestExpandQuery(t *testing.T) {
+	var got string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		got = r.URL.Query().Get("expand")
+		fmt.Fprint(w, `{}`)
+	}))
+	defer srv.Close()
+
+	clnt, err := NewClient(WithToken("token"), WithBaseURL(srv.URL), WithRateLimit(0),
+		WithExpand(ExpandOrganizer, ExpandAttendees))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name string
+		call func(ctx context.Context) error
+		want string
+	}{
+		{"event", func(ctx context.Context) error {
+			_, err := clnt.EventGet(ctx, "1")
+			return err
+		}, "organizer"},
+		{"order", func(ctx context.Context) error {
+			_, err := clnt.OrderGet(ctx, "1")
+			return err
+		}, "attendees"},
+		{"per call", func(ctx context.Context) error {
+			_, err := clnt.EventGet(ContextWithExpand(ctx, ExpandTicketClasses, ExpandRefundRequests), "1")
+			return err
+		}, "ticket_classes"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.call(context.Background()); err != nil {
+				t.Fatal(err)
+			}
+			if got != tt.want {
+				t.Errorf("expand = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
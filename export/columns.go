@@ -0,0 +1,195 @@
+package export
+
+import (
+	"encoding/json"
+	"math"
+	"strconv"
+	"strings"
+
+	"github.com/apzuk3/go-eventbrite"
+)
+
+// AttendeeColumns are the columns available for attendees, with the profile and addresses
+// flattened to dotted names like profile.email or addresses.home.city. The answers to custom
+// questions are joined in the answers column, AnswerColumn exports a single one.
+var AttendeeColumns = append(append([]Column[eventbrite.Attendee]{
+	{"id", func(a eventbrite.Attendee) any { return a.ID }},
+	{"created", func(a eventbrite.Attendee) any { return formatDateTime(a.Created) }},
+	{"changed", func(a eventbrite.Attendee) any { return formatDateTime(a.Changed) }},
+	{"event_id", func(a eventbrite.Attendee) any { return a.EventID }},
+	{"order_id", func(a eventbrite.Attendee) any { return a.OrderID }},
+	{"ticket_class_name", func(a eventbrite.Attendee) any { return a.TicketClassName }},
+	{"status", func(a eventbrite.Attendee) any { return a.Status }},
+	{"checked_in", func(a eventbrite.Attendee) any { return a.CheckedIn }},
+	{"cancelled", func(a eventbrite.Attendee) any { return a.Cancelled }},
+	{"refunded", func(a eventbrite.Attendee) any { return a.Refunded }},
+	{"barcodes", func(a eventbrite.Attendee) any { return joinBarcodes(a.Barcodes) }},
+	{"team.name", func(a eventbrite.Attendee) any { return a.Team.Name }},
+	{"profile.name", func(a eventbrite.Attendee) any { return a.Profile.Name }},
+	{"profile.email", func(a eventbrite.Attendee) any { return a.Profile.Email }},
+	{"profile.first_name", func(a eventbrite.Attendee) any { return a.Profile.FirstName }},
+	{"profile.last_name", func(a eventbrite.Attendee) any { return a.Profile.LastName }},
+	{"profile.prefix", func(a eventbrite.Attendee) any { return a.Profile.Prefix }},
+	{"profile.suffix", func(a eventbrite.Attendee) any { return a.Profile.Suffix }},
+	{"profile.age", func(a eventbrite.Attendee) any { return optionalInt(a.Profile.Age) }},
+	{"profile.job_title", func(a eventbrite.Attendee) any { return a.Profile.JobTitle }},
+	{"profile.company", func(a eventbrite.Attendee) any { return a.Profile.Company }},
+	{"profile.website", func(a eventbrite.Attendee) any { return a.Profile.Website }},
+	{"profile.blog", func(a eventbrite.Attendee) any { return a.Profile.Blog }},
+	{"profile.gender", func(a eventbrite.Attendee) any { return a.Profile.Gender }},
+	{"profile.birth_date", func(a eventbrite.Attendee) any { return formatDate(a.Profile.BirthDate) }},
+	{"profile.cell_phone", func(a eventbrite.Attendee) any { return a.Profile.CellPhone }},
+},
+	addressColumns()...),
+	Column[eventbrite.Attendee]{"answers", func(a eventbrite.Attendee) any { return joinAnswers(a.Answers) }},
+)
+
+// addressColumns returns the columns of the home, ship and work addresses of attendees
+func addressColumns() []Column[eventbrite.Attendee] {
+	addresses := []struct {
+		name string
+		get  func(eventbrite.Attendee) eventbrite.Address
+	}{
+		{"home", func(a eventbrite.Attendee) eventbrite.Address { return a.Addresses.Home }},
+		{"ship", func(a eventbrite.Attendee) eventbrite.Address { return a.Addresses.Ship }},
+		{"work", func(a eventbrite.Attendee) eventbrite.Address { return a.Addresses.Work }},
+	}
+	fields := []struct {
+		name string
+		get  func(eventbrite.Address) string
+	}{
+		{"address_1", func(a eventbrite.Address) string { return a.Address1 }},
+		{"address_2", func(a eventbrite.Address) string { return a.Address2 }},
+		{"city", func(a eventbrite.Address) string { return a.City }},
+		{"region", func(a eventbrite.Address) string { return a.Region }},
+		{"postal_code", func(a eventbrite.Address) string { return a.PostalCode }},
+		{"country", func(a eventbrite.Address) string { return a.Country }},
+	}
+
+	columns := make([]Column[eventbrite.Attendee], 0, len(addresses)*len(fields))
+	for _, address := range addresses {
+		for _, field := range fields {
+			address, field := address, field
+			columns = append(columns, Column[eventbrite.Attendee]{
+				Name:  "addresses." + address.name + "." + field.name,
+				Value: func(a eventbrite.Attendee) any { return field.get(address.get(a)) },
+			})
+		}
+	}
+	return columns
+}
+
+// AnswerColumn returns a column holding the answer of attendees to a custom question, named
+// after name
+func AnswerColumn(questionID, name string) Column[eventbrite.Attendee] {
+	return Column[eventbrite.Attendee]{name, func(a eventbrite.Attendee) any {
+		for _, answer := range a.Answers {
+			if answer.QuestionID == questionID {
+				return answer.Answer
+			}
+		}
+		return ""
+	}}
+}
+
+// OrderColumns are the columns available for orders, with the costs flattened to dotted names
+// like costs.gross
+var OrderColumns = []Column[eventbrite.Order]{
+	{"id", func(o eventbrite.Order) any { return o.ID }},
+	{"created", func(o eventbrite.Order) any { return formatDateTime(o.Created) }},
+	{"changed", func(o eventbrite.Order) any { return formatDateTime(o.Changed) }},
+	{"event_id", func(o eventbrite.Order) any { return o.EventID }},
+	{"name", func(o eventbrite.Order) any { return o.Name }},
+	{"first_name", func(o eventbrite.Order) any { return o.FirstName }},
+	{"last_name", func(o eventbrite.Order) any { return o.LastName }},
+	{"email", func(o eventbrite.Order) any { return o.Email }},
+	{"attendees", func(o eventbrite.Order) any { return len(o.Attendees) }},
+	{"costs.currency", func(o eventbrite.Order) any { return string(o.Costs.Gross.Currency) }},
+	{"costs.gross", func(o eventbrite.Order) any { return formatAmount(o.Costs.Gross) }},
+	{"costs.eventbrite_fee", func(o eventbrite.Order) any { return formatAmount(o.Costs.EventbriteFee) }},
+	{"costs.payment_fee", func(o eventbrite.Order) any { return formatAmount(o.Costs.PaymentFee) }},
+	{"costs.tax", func(o eventbrite.Order) any { return formatAmount(o.Costs.Tex) }},
+}
+
+// ReportColumns are the columns of the rows of a report, named after eventbrite.ReportHeader
+var ReportColumns = []Column[eventbrite.ReportRow]{
+	{"date", func(r eventbrite.ReportRow) any { return r.Date }},
+	{"name", func(r eventbrite.ReportRow) any { return r.Name }},
+	{"currency", func(r eventbrite.ReportRow) any { return r.Currency }},
+	{"gross", func(r eventbrite.ReportRow) any { return r.Gross }},
+	{"net", func(r eventbrite.ReportRow) any { return r.Net }},
+	{"fees", func(r eventbrite.ReportRow) any { return r.Fees }},
+	{"quantity", func(r eventbrite.ReportRow) any { return r.Quantity }},
+	{"num_attendees", func(r eventbrite.ReportRow) any { return r.NumAttendees }},
+}
+
+// formatDateTime formats a date-time in UTC, nil when it is not set
+func formatDateTime(d eventbrite.DateTime) any {
+	if d.Time.IsZero() {
+		return nil
+	}
+	return d.Time.UTC().Format("2006-01-02T15:04:05Z")
+}
+
+// formatDate formats a date, nil when it is not set
+func formatDate(d eventbrite.Date) any {
+	if d.Time.IsZero() {
+		return nil
+	}
+	return d.Time.Format("2006-01-02")
+}
+
+// optionalInt returns i, nil when it is not set
+func optionalInt(i int) any {
+	if i == 0 {
+		return nil
+	}
+	return i
+}
+
+// minorUnitExponents are the ISO 4217 currencies whose minor unit is not a hundredth of the
+// major one, like the yen which has none or the Kuwaiti dinar which has thousandths
+var minorUnitExponents = map[eventbrite.CurrencyCode]int{
+	"BIF": 0, "CLP": 0, "DJF": 0, "GNF": 0, "ISK": 0, "JPY": 0, "KMF": 0, "KRW": 0, "PYG": 0,
+	"RWF": 0, "UGX": 0, "UYI": 0, "VND": 0, "VUV": 0, "XAF": 0, "XOF": 0, "XPF": 0,
+	"BHD": 3, "IQD": 3, "JOD": 3, "KWD": 3, "LYD": 3, "OMR": 3, "TND": 3,
+	"CLF": 4, "UYW": 4,
+}
+
+// minorUnitExponent returns the number of decimals of the currency, 2 for the unknown ones
+func minorUnitExponent(code eventbrite.CurrencyCode) int {
+	if e, ok := minorUnitExponents[eventbrite.CurrencyCode(strings.ToUpper(string(code)))]; ok {
+		return e
+	}
+	return 2
+}
+
+// formatAmount formats the value of an amount, which the API gives in minor units, as a number
+// with the decimals of its currency. It is nil when the amount is not set.
+func formatAmount(c eventbrite.Currency) any {
+	if c.Currency == "" && c.Value == 0 {
+		return nil
+	}
+	exp := minorUnitExponent(c.Currency)
+	return json.Number(strconv.FormatFloat(float64(c.Value)/math.Pow10(exp), 'f', exp, 64))
+}
+
+func joinBarcodes(barcodes []eventbrite.AttendeeBarcodes) string {
+	values := make([]string, 0, len(barcodes))
+	for _, b := range barcodes {
+		values = append(values, b.Barcode)
+	}
+	return strings.Join(values, ",")
+}
+
+// joinAnswers joins the answers as "question: answer" pairs separated by semicolons
+func joinAnswers(answers []eventbrite.AttendeeAnswers) string {
+	values := make([]string, 0, len(answers))
+	for _, a := range answers {
+		if a.Answer == "" {
+			continue
+		}
+		values = append(values, a.Question+": "+a.Answer)
+	}
+	return strings.Join(values, "; ")
+}
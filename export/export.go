@@ -0,0 +1,202 @@
+// Package export writes attendees, orders and report rows as CSV or newline-delimited JSON, for
+// spreadsheets and data warehouses.
+//
+// Rows are written one at a time, so exporting from an Iterator streams the paginated list
+// endpoints without loading a large event into memory:
+//
+//	columns, err := export.Select(export.AttendeeColumns, "id", "profile.email", "addresses.work.city")
+//	if err != nil {
+//		// handle me
+//	}
+//	w, _ := export.NewWriter(os.Stdout, export.CSV, columns)
+//	n, err := export.Copy(w, clnt.EventAttendeesIterator(ctx, "123", nil))
+package export
+
+import (
+	"bufio"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+
+	"github.com/apzuk3/go-eventbrite"
+)
+
+// Format is an output format
+type Format string
+
+// Supported output formats
+const (
+	// Comma separated values, with a header line naming the columns
+	CSV Format = "csv"
+	// One JSON object per line, keyed by column name, with numbers and booleans typed
+	NDJSON Format = "ndjson"
+)
+
+// Column is a named field of the exported rows. Values are strings, bools, ints, float64s or
+// json.Numbers, and nil for an empty value. CSV writes them as text, NDJSON keeps their JSON
+// type and writes nil as null.
+type Column[T any] struct {
+	Name  string
+	Value func(T) any
+}
+
+// Select returns the columns with the given names, in the given order. It fails on an unknown
+// name, so that a typo does not silently produce an empty column.
+func Select[T any](columns []Column[T], names ...string) ([]Column[T], error) {
+	res := make([]Column[T], 0, len(names))
+	for _, name := range names {
+		found := false
+		for _, col := range columns {
+			if col.Name == name {
+				res = append(res, col)
+				found = true
+				break
+			}
+		}
+		if !found {
+			return nil, fmt.Errorf("export: unknown column %q", name)
+		}
+	}
+	return res, nil
+}
+
+// Names returns the names of the columns
+func Names[T any](columns []Column[T]) []string {
+	names := make([]string, len(columns))
+	for i, col := range columns {
+		names[i] = col.Name
+	}
+	return names
+}
+
+// Writer writes rows in an output format. Writes are buffered, Flush must be called once done.
+type Writer[T any] interface {
+	Write(item T) error
+	Flush() error
+}
+
+// NewWriter returns a Writer of the given columns in the given format
+func NewWriter[T any](w io.Writer, format Format, columns []Column[T]) (Writer[T], error) {
+	switch format {
+	case CSV:
+		return &csvWriter[T]{w: csv.NewWriter(w), columns: columns}, nil
+	case NDJSON:
+		return &jsonWriter[T]{w: bufio.NewWriter(w), columns: columns}, nil
+	}
+	return nil, fmt.Errorf("export: unknown format %q, want csv or ndjson", format)
+}
+
+// Copy writes every item of the iterator then flushes the writer. It returns the number of
+// items written.
+func Copy[T any](w Writer[T], it *eventbrite.Iterator[T]) (int, error) {
+	n := 0
+	for it.Next() {
+		if err := w.Write(it.Item()); err != nil {
+			return n, err
+		}
+		n++
+	}
+	if err := it.Err(); err != nil {
+		w.Flush()
+		return n, err
+	}
+	return n, w.Flush()
+}
+
+// WriteAll writes the items then flushes the writer
+func WriteAll[T any](w Writer[T], items []T) error {
+	for _, item := range items {
+		if err := w.Write(item); err != nil {
+			return err
+		}
+	}
+	return w.Flush()
+}
+
+type csvWriter[T any] struct {
+	w       *csv.Writer
+	columns []Column[T]
+	header  bool
+	record  []string
+}
+
+func (w *csvWriter[T]) writeHeader() error {
+	if w.header {
+		return nil
+	}
+	w.header = true
+	return w.w.Write(Names(w.columns))
+}
+
+func (w *csvWriter[T]) Write(item T) error {
+	if err := w.writeHeader(); err != nil {
+		return err
+	}
+	if w.record == nil {
+		w.record = make([]string, len(w.columns))
+	}
+	for i, col := range w.columns {
+		w.record[i] = cell(col.Value(item))
+	}
+	return w.w.Write(w.record)
+}
+
+// cell formats a value as the text of a CSV cell
+func cell(v any) string {
+	switch v := v.(type) {
+	case nil:
+		return ""
+	case string:
+		return v
+	case bool:
+		return strconv.FormatBool(v)
+	case int:
+		return strconv.Itoa(v)
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	case json.Number:
+		return v.String()
+	}
+	return fmt.Sprint(v)
+}
+
+// Flush writes the header even when there was no row, so that an empty export is still a
+// valid CSV file
+func (w *csvWriter[T]) Flush() error {
+	if err := w.writeHeader(); err != nil {
+		return err
+	}
+	w.w.Flush()
+	return w.w.Error()
+}
+
+type jsonWriter[T any] struct {
+	w       *bufio.Writer
+	columns []Column[T]
+}
+
+// Write writes the item as a JSON object whose keys follow the order of the columns
+func (w *jsonWriter[T]) Write(item T) error {
+	w.w.WriteByte('{')
+	for i, col := range w.columns {
+		if i > 0 {
+			w.w.WriteByte(',')
+		}
+		key, _ := json.Marshal(col.Name)
+		value, err := json.Marshal(col.Value(item))
+		if err != nil {
+			return fmt.Errorf("export: column %s: %w", col.Name, err)
+		}
+		w.w.Write(key)
+		w.w.WriteByte(':')
+		w.w.Write(value)
+	}
+	_, err := w.w.WriteString("}\n")
+	return err
+}
+
+func (w *jsonWriter[T]) Flush() error {
+	return w.w.Flush()
+}
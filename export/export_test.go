@@ -0,0 +1,89 @@
+package export
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+
+	"github.com/apzuk3/go-eventbrite"
+)
+
+func TestWriter(t *testing.T) {
+	orders := []eventbrite.Order{
+		{ID: "1", Name: "Ada Lovelace", Costs: eventbrite.OrderCosts{Gross: eventbrite.Currency{Currency: "USD", Value: 2550}}, Attendees: make([]eventbrite.Attendee, 2)},
+		{ID: "2"},
+	}
+	columns, err := Select(OrderColumns, "id", "name", "attendees", "costs.gross", "created")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		format Format
+		want   string
+	}{
+		{CSV, "id,name,attendees,costs.gross,created\n1,Ada Lovelace,2,25.50,\n2,,0,,\n"},
+		{NDJSON, `{"id":"1","name":"Ada Lovelace","attendees":2,"costs.gross":25.50,"created":null}` + "\n" +
+			`{"id":"2","name":"","attendees":0,"costs.gross":null,"created":null}` + "\n"},
+	}
+
+	for _, tt := range tests {
+		t.Run(string(tt.format), func(t *testing.T) {
+			var buf bytes.Buffer
+			w, err := NewWriter(&buf, tt.format, columns)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if err := WriteAll(w, orders); err != nil {
+				t.Fatal(err)
+			}
+			if buf.String() != tt.want {
+				t.Errorf("got\n%s\nwant\n%s", buf.String(), tt.want)
+			}
+		})
+	}
+}
+
+func TestReportColumns(t *testing.T) {
+	if got := Names(ReportColumns); len(got) != len(eventbrite.ReportHeader) {
+		t.Fatalf("columns = %v, want %v", got, eventbrite.ReportHeader)
+	}
+	for i, name := range Names(ReportColumns) {
+		if name != eventbrite.ReportHeader[i] {
+			t.Errorf("column %d = %s, want %s", i, name, eventbrite.ReportHeader[i])
+		}
+	}
+
+	var buf bytes.Buffer
+	w, _ := NewWriter(&buf, NDJSON, ReportColumns)
+	row := eventbrite.ReportRow{Date: "2026-07-01", ReportTotals: eventbrite.ReportTotals{Currency: "USD", Gross: 12.5, Quantity: 3}}
+	if err := WriteAll(w, []eventbrite.ReportRow{row}); err != nil {
+		t.Fatal(err)
+	}
+	want := `{"date":"2026-07-01","name":"","currency":"USD","gross":12.5,"net":0,"fees":0,"quantity":3,"num_attendees":0}` + "\n"
+	if buf.String() != want {
+		t.Errorf("got %s want %s", buf.String(), want)
+	}
+}
+
+func TestFormatAmount(t *testing.T) {
+	tests := []struct {
+		amount eventbrite.Currency
+		want   any
+	}{
+		{eventbrite.Currency{Currency: "USD", Value: 2550}, json.Number("25.50")},
+		{eventbrite.Currency{Currency: "eur", Value: 5}, json.Number("0.05")},
+		{eventbrite.Currency{Currency: "JPY", Value: 2550}, json.Number("2550")},
+		{eventbrite.Currency{Currency: "KRW", Value: 15000}, json.Number("15000")},
+		{eventbrite.Currency{Currency: "KWD", Value: 2550}, json.Number("2.550")},
+		{eventbrite.Currency{Currency: "BHD", Value: 1}, json.Number("0.001")},
+		{eventbrite.Currency{Currency: "USD"}, json.Number("0.00")},
+		{eventbrite.Currency{}, nil},
+	}
+
+	for _, tt := range tests {
+		if got := formatAmount(tt.amount); got != tt.want {
+			t.Errorf("formatAmount(%+v) = %v, want %v", tt.amount, got, tt.want)
+		}
+	}
+}
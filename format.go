@@ -29,7 +29,7 @@ type Format struct {
 func (c *Client) Formats(ctx context.Context) (*FormatResult, error) {
 	res := new(FormatResult)
 
-	return res, c.getJSON(ctx, "/formats", nil, res)
+	return res, c.getJSON(ctx, "Formats", "/formats", nil, res)
 }
 
 // Format gets a format by ID as format.
@@ -38,5 +38,5 @@ func (c *Client) Formats(ctx context.Context) (*FormatResult, error) {
 func (c *Client) Format(ctx context.Context, id string) (*Format, error) {
 	res := new(Format)
 
-	return res, c.getJSON(ctx, "/formats/"+id, nil, res)
+	return res, c.getJSON(ctx, "Format", "/formats/"+id, nil, res)
 }
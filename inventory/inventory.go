@@ -0,0 +1,360 @@
+// Package inventory reasons about the ticket ladder of an event: the remaining capacity of its
+// ticket classes and ticket groups, the transitions from one tier to the next through
+// sales_start_after, and the mistakes a ladder can hide, like overlapping sale windows or more
+// tickets than the event can hold.
+//
+//	inv, err := inventory.Load(ctx, clnt, "123")
+//	if err != nil {
+//		// handle me
+//	}
+//	for _, issue := range inv.Check() {
+//		fmt.Println(issue)
+//	}
+//
+// A ladder declared as a list of tiers is applied through the ticket class endpoints
+//
+//	plan, err := inv.Plan(inventory.Ladder{
+//		{Name: "Early bird", QuantityTotal: 50, Cost: "USD,2500"},
+//		{Name: "Regular", QuantityTotal: 150, Cost: "USD,4000"},
+//	})
+//	classes, err := inv.Apply(ctx, clnt, plan)
+package inventory
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/apzuk3/go-eventbrite"
+
+	"golang.org/x/net/context"
+)
+
+// timeLayout is the format of the sale times of a ticket class
+const timeLayout = "2006-01-02T15:04:05Z"
+
+// Inventory is the ticket classes and ticket groups of an event at the time it was loaded
+type Inventory struct {
+	Event   eventbrite.Event
+	Classes []eventbrite.TicketClass
+	Groups  []eventbrite.TicketGroup
+}
+
+// Load fetches an event along with its ticket classes and its live ticket groups
+func Load(ctx context.Context, client *eventbrite.Client, eventID string) (*Inventory, error) {
+	e, err := client.EventGet(ctx, eventID)
+	if err != nil {
+		return nil, err
+	}
+	inv := &Inventory{Event: *e}
+
+	err = client.EventTicketClassesIterator(ctx, eventID, nil).Walk(func(tc eventbrite.TicketClass) error {
+		inv.Classes = append(inv.Classes, tc)
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("inventory: list ticket classes: %w", err)
+	}
+
+	err = client.EventTicketGroupsIterator(ctx, eventID, nil).Walk(func(g eventbrite.TicketGroup) error {
+		inv.Groups = append(inv.Groups, g)
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("inventory: list ticket groups: %w", err)
+	}
+	return inv, nil
+}
+
+// Class returns the ticket class with the given ID, nil when the event has none
+func (inv *Inventory) Class(id string) *eventbrite.TicketClass {
+	for i := range inv.Classes {
+		if inv.Classes[i].ID == id {
+			return &inv.Classes[i]
+		}
+	}
+	return nil
+}
+
+// Remaining returns the number of tickets of a class still for sale, never below zero
+func Remaining(tc eventbrite.TicketClass) int {
+	if tc.QuantitySold >= tc.QuantityTotal {
+		return 0
+	}
+	return tc.QuantityTotal - tc.QuantitySold
+}
+
+// Remaining returns the number of tickets of the event still for sale, bounded by the event
+// capacity when it is set
+func (inv *Inventory) Remaining() int {
+	var remaining, sold int
+	for _, tc := range inv.Classes {
+		remaining += Remaining(tc)
+		sold += tc.QuantitySold
+	}
+
+	if c := inv.Event.Capacity; c > 0 && remaining > c-sold {
+		remaining = c - sold
+	}
+	if remaining < 0 {
+		return 0
+	}
+	return remaining
+}
+
+// GroupRemaining returns the number of tickets still for sale in the classes of a ticket group
+// belonging to the event
+func (inv *Inventory) GroupRemaining(g eventbrite.TicketGroup) int {
+	n := 0
+	for _, id := range inv.groupClasses(g) {
+		if tc := inv.Class(id); tc != nil {
+			n += Remaining(*tc)
+		}
+	}
+	return n
+}
+
+// groupClasses returns the IDs of the ticket classes of the event in a ticket group, taken from
+// its event_ticket_ids or, failing that, from its tickets expansion
+func (inv *Inventory) groupClasses(g eventbrite.TicketGroup) []string {
+	var res []string
+	if ids, ok := g.EventTicketIds.(map[string]interface{}); ok {
+		if list, ok := ids[inv.Event.Id].([]interface{}); ok {
+			for _, id := range list {
+				res = append(res, fmt.Sprint(id))
+			}
+			return res
+		}
+	}
+
+	for _, tc := range g.Tickets {
+		if tc.EventID == "" || tc.EventID == inv.Event.Id {
+			res = append(res, tc.ID)
+		}
+	}
+	return res
+}
+
+// Transition is the move from a tier to the next one, which goes on sale when the first sells out
+type Transition struct {
+	From eventbrite.TicketClass
+	To   eventbrite.TicketClass
+}
+
+// Transitions returns the tier transitions of the event, declared with sales_start_after
+func (inv *Inventory) Transitions() []Transition {
+	var res []Transition
+	for _, tc := range inv.Classes {
+		if tc.SalesStartAfter == "" {
+			continue
+		}
+		if from := inv.Class(tc.SalesStartAfter); from != nil {
+			res = append(res, Transition{From: *from, To: tc})
+		}
+	}
+	return res
+}
+
+// Ladders returns the chains of ticket classes linked by sales_start_after, each starting with
+// a class which goes on sale on its own. A class outside of any transition is a ladder of one.
+// The classes of a cycle are not part of any ladder, Check reports them.
+func (inv *Inventory) Ladders() [][]eventbrite.TicketClass {
+	next := map[string][]eventbrite.TicketClass{}
+	for _, tc := range inv.Classes {
+		if tc.SalesStartAfter != "" && inv.Class(tc.SalesStartAfter) != nil {
+			next[tc.SalesStartAfter] = append(next[tc.SalesStartAfter], tc)
+		}
+	}
+
+	var res [][]eventbrite.TicketClass
+	var walk func(ladder []eventbrite.TicketClass)
+	walk = func(ladder []eventbrite.TicketClass) {
+		last := ladder[len(ladder)-1]
+		if len(next[last.ID]) == 0 {
+			res = append(res, ladder)
+			return
+		}
+		for _, tc := range next[last.ID] {
+			walk(append(append([]eventbrite.TicketClass(nil), ladder...), tc))
+		}
+	}
+
+	for _, tc := range inv.Classes {
+		if tc.SalesStartAfter == "" || inv.Class(tc.SalesStartAfter) == nil {
+			walk([]eventbrite.TicketClass{tc})
+		}
+	}
+	return res
+}
+
+// OnSale returns the ticket classes on sale at the given time: not sold out, within their sale
+// window, and whose previous tier, if any, is sold out
+func (inv *Inventory) OnSale(at time.Time) []eventbrite.TicketClass {
+	var res []eventbrite.TicketClass
+	for _, tc := range inv.Classes {
+		if Remaining(tc) == 0 {
+			continue
+		}
+		if prev := inv.Class(tc.SalesStartAfter); prev != nil && Remaining(*prev) > 0 {
+			continue
+		}
+		if w := inv.window(tc); w.contains(at) {
+			res = append(res, tc)
+		}
+	}
+	return res
+}
+
+// window is the sale window of a ticket class, an unbounded side being zero
+type window struct {
+	start, end time.Time
+}
+
+func (w window) contains(t time.Time) bool {
+	return (w.start.IsZero() || !t.Before(w.start)) && (w.end.IsZero() || t.Before(w.end))
+}
+
+func (w window) overlaps(o window) bool {
+	return (w.start.IsZero() || o.end.IsZero() || w.start.Before(o.end)) &&
+		(o.start.IsZero() || w.end.IsZero() || o.start.Before(w.end))
+}
+
+// window returns the sale window of a ticket class. Sales end one hour before the event starts
+// unless the class says otherwise.
+func (inv *Inventory) window(tc eventbrite.TicketClass) window {
+	var w window
+	w.start, _ = time.Parse(timeLayout, tc.SalesStart)
+	w.end, _ = time.Parse(timeLayout, tc.SalesEnd)
+	if tc.SalesEnd == "" {
+		if start, err := time.Parse(timeLayout, inv.Event.Start.Utc); err == nil {
+			w.end = start.Add(-time.Hour)
+		}
+	}
+	return w
+}
+
+// IssueKind is the kind of a mistake found in a ticket ladder
+type IssueKind string
+
+// Kinds of issues reported by Check
+const (
+	// Two tiers of a ladder can be on sale at the same time
+	IssueOverlap IssueKind = "overlap"
+	// The ticket classes hold more tickets than the event capacity
+	IssueOversubscribed IssueKind = "oversubscribed"
+	// More tickets have been sold than the event capacity
+	IssueOversold IssueKind = "oversold"
+	// A ticket class goes on sale after a class which does not exist
+	IssueMissingPrevious IssueKind = "missing_previous"
+	// Ticket classes go on sale after one another in a cycle, none of them ever goes on sale
+	IssueCycle IssueKind = "cycle"
+	// A ticket class stops selling before it starts
+	IssueEmptyWindow IssueKind = "empty_window"
+)
+
+// Issue is a mistake found in a ticket ladder
+type Issue struct {
+	Kind IssueKind
+	// The IDs of the ticket classes involved
+	Classes []string
+	// A human readable explanation
+	Message string
+}
+
+func (i Issue) String() string {
+	return string(i.Kind) + ": " + i.Message
+}
+
+// Check looks for the mistakes of the ticket ladder of the event
+func (inv *Inventory) Check() []Issue {
+	var res []Issue
+
+	total, sold := 0, 0
+	for _, tc := range inv.Classes {
+		total += tc.QuantityTotal
+		sold += tc.QuantitySold
+
+		if prev := tc.SalesStartAfter; prev != "" && inv.Class(prev) == nil {
+			res = append(res, Issue{
+				Kind:    IssueMissingPrevious,
+				Classes: []string{tc.ID},
+				Message: fmt.Sprintf("%s goes on sale after ticket class %s, which does not exist", tc.Name, prev),
+			})
+		}
+
+		if w := inv.window(tc); !w.start.IsZero() && !w.end.IsZero() && !w.start.Before(w.end) {
+			res = append(res, Issue{
+				Kind:    IssueEmptyWindow,
+				Classes: []string{tc.ID},
+				Message: fmt.Sprintf("%s stops selling at %s, before it starts at %s", tc.Name, w.end.Format(timeLayout), w.start.Format(timeLayout)),
+			})
+		}
+	}
+
+	if c := inv.Event.Capacity; c > 0 {
+		if total > c {
+			res = append(res, Issue{
+				Kind:    IssueOversubscribed,
+				Message: fmt.Sprintf("ticket classes hold %d tickets, the event capacity is %d", total, c),
+			})
+		}
+		if sold > c {
+			res = append(res, Issue{
+				Kind:    IssueOversold,
+				Message: fmt.Sprintf("%d tickets sold, the event capacity is %d", sold, c),
+			})
+		}
+	}
+
+	res = append(res, inv.cycles()...)
+
+	// the tiers of a ladder are meant to sell one after the other
+	seen := map[[2]string]bool{}
+	for _, ladder := range inv.Ladders() {
+		for i := 0; i < len(ladder); i++ {
+			for j := i + 1; j < len(ladder); j++ {
+				a, b := ladder[i], ladder[j]
+				key := [2]string{a.ID, b.ID}
+				// a later tier without a sales start of its own waits for the previous one
+				if seen[key] || b.SalesStart == "" || !inv.window(a).overlaps(inv.window(b)) {
+					continue
+				}
+				seen[key] = true
+				res = append(res, Issue{
+					Kind:    IssueOverlap,
+					Classes: []string{a.ID, b.ID},
+					Message: fmt.Sprintf("%s and %s, tiers of the same ladder, can be on sale at the same time", a.Name, b.Name),
+				})
+			}
+		}
+	}
+	return res
+}
+
+// cycles reports the ticket classes going on sale after one another in a loop
+func (inv *Inventory) cycles() []Issue {
+	var res []Issue
+	done := map[string]bool{}
+	for _, tc := range inv.Classes {
+		path := []string{}
+		onPath := map[string]int{}
+		for cur := inv.Class(tc.ID); cur != nil && !done[cur.ID]; cur = inv.Class(cur.SalesStartAfter) {
+			if i, ok := onPath[cur.ID]; ok {
+				cycle := append([]string(nil), path[i:]...)
+				sort.Strings(cycle)
+				res = append(res, Issue{
+					Kind:    IssueCycle,
+					Classes: cycle,
+					Message: fmt.Sprintf("ticket classes %v go on sale after one another in a cycle", cycle),
+				})
+				break
+			}
+			onPath[cur.ID] = len(path)
+			path = append(path, cur.ID)
+		}
+		for _, id := range path {
+			done[id] = true
+		}
+	}
+	return res
+}
@@ -0,0 +1,168 @@
+package inventory
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/apzuk3/go-eventbrite"
+
+	"golang.org/x/net/context"
+)
+
+// Tier is a step of a declared ticket ladder
+type Tier struct {
+	// The name of the ticket class, which identifies the tier among the existing classes
+	Name string
+	// The number of tickets of the tier
+	QuantityTotal int
+	// The cost of a ticket, like "USD,4500" for $45, empty for a free tier
+	Cost string
+	// When the tier stops selling, empty to sell until it sells out or the event starts
+	SalesEnd string
+}
+
+// Ladder is a list of tiers going on sale one after the other: the first one as soon as the
+// event is published, every other one when the previous one sells out
+type Ladder []Tier
+
+// Change is a ticket class to create or update to apply a ladder
+type Change struct {
+	// The ID of the ticket class to update, empty to create one
+	ClassID string
+	// The name of the tier
+	Name string
+	// The fields to send. SalesStartAfter is resolved when the change is applied, it is only
+	// set by the plan to clear the one of the first tier.
+	Fields eventbrite.TicketClassFields
+	// The name of the tier the class goes on sale after, empty for the first tier
+	After string
+}
+
+// Create reports whether the change creates a ticket class
+func (c Change) Create() bool {
+	return c.ClassID == ""
+}
+
+// Plan returns the changes applying a ladder to the ticket classes of the event. Tiers are
+// matched with the classes by name, the classes without a tier are left untouched. Tiers which
+// already match their class are omitted, and a ladder holding, along with the untouched
+// classes, more tickets than the event capacity is refused.
+func (inv *Inventory) Plan(l Ladder) ([]Change, error) {
+	byName := map[string]eventbrite.TicketClass{}
+	for _, tc := range inv.Classes {
+		byName[tc.Name] = tc
+	}
+
+	total := 0
+	seen := map[string]bool{}
+	for _, t := range l {
+		if t.Name == "" {
+			return nil, errors.New("inventory: tier without a name")
+		}
+		if seen[t.Name] {
+			return nil, fmt.Errorf("inventory: tier %q declared twice", t.Name)
+		}
+		seen[t.Name] = true
+
+		if tc, ok := byName[t.Name]; ok && t.QuantityTotal < tc.QuantitySold {
+			return nil, fmt.Errorf("inventory: tier %q has %d tickets, %d are already sold", t.Name, t.QuantityTotal, tc.QuantitySold)
+		}
+		total += t.QuantityTotal
+	}
+	// the classes without a tier stay on sale as they are
+	for _, tc := range inv.Classes {
+		if !seen[tc.Name] {
+			total += tc.QuantityTotal
+		}
+	}
+	if c := inv.Event.Capacity; c > 0 && total > c {
+		return nil, fmt.Errorf("inventory: ladder holds %d tickets, the event capacity is %d", total, c)
+	}
+
+	var res []Change
+	for i, t := range l {
+		ch := Change{Name: t.Name}
+		if i > 0 {
+			ch.After = l[i-1].Name
+		}
+
+		tc, exists := byName[t.Name]
+		if exists {
+			ch.ClassID = tc.ID
+			prev := ""
+			if p := inv.Class(tc.SalesStartAfter); p != nil {
+				prev = p.Name
+			}
+			if tc.QuantityTotal == t.QuantityTotal && cost(tc) == t.Cost && tc.SalesEnd == t.SalesEnd && prev == ch.After {
+				continue
+			}
+		}
+
+		ch.Fields = eventbrite.TicketClassFields{
+			Name:          t.Name,
+			QuantityTotal: t.QuantityTotal,
+			Cost:          t.Cost,
+			SalesEnd:      t.SalesEnd,
+			Free:          eventbrite.Bool(t.Cost == ""),
+		}
+		// a class becoming the first tier no longer waits for another one
+		if exists && ch.After == "" && tc.SalesStartAfter != "" {
+			ch.Fields.SalesStartAfter = eventbrite.String("")
+		}
+		res = append(res, ch)
+	}
+	return res, nil
+}
+
+// cost formats the cost of a ticket class the way it is sent, empty when free
+func cost(tc eventbrite.TicketClass) string {
+	if tc.Free {
+		return ""
+	}
+	return fmt.Sprintf("%s,%d", tc.Cost.Currency, int64(tc.Cost.Value))
+}
+
+// Apply runs the changes of a plan in order, creating or updating the ticket classes, and
+// returns the classes as updated. The classes of the inventory are refreshed along the way, so
+// a failed apply can be planned and applied again.
+func (inv *Inventory) Apply(ctx context.Context, client *eventbrite.Client, changes []Change) ([]eventbrite.TicketClass, error) {
+	var res []eventbrite.TicketClass
+	for _, ch := range changes {
+		fields := ch.Fields
+		if ch.After != "" {
+			prev := inv.byName(ch.After)
+			if prev == nil {
+				return res, fmt.Errorf("inventory: tier %q goes on sale after %q, which does not exist", ch.Name, ch.After)
+			}
+			fields.SalesStartAfter = eventbrite.String(prev.ID)
+		}
+
+		var tc *eventbrite.TicketClass
+		var err error
+		if ch.Create() {
+			tc, err = client.EventCreateTicketClass(ctx, inv.Event.Id, &eventbrite.EventCreateTicketClass{TicketClass: fields})
+		} else {
+			tc, err = client.EventUpdateTicketClass(ctx, inv.Event.Id, ch.ClassID, &eventbrite.EventUpdateTicketClass{TicketClass: fields})
+		}
+		if err != nil {
+			return res, fmt.Errorf("inventory: apply tier %q: %w", ch.Name, err)
+		}
+
+		if cur := inv.Class(tc.ID); cur != nil {
+			*cur = *tc
+		} else {
+			inv.Classes = append(inv.Classes, *tc)
+		}
+		res = append(res, *tc)
+	}
+	return res, nil
+}
+
+func (inv *Inventory) byName(name string) *eventbrite.TicketClass {
+	for i := range inv.Classes {
+		if inv.Classes[i].Name == name {
+			return &inv.Classes[i]
+		}
+	}
+	return nil
+}
@@ -0,0 +1,146 @@
+package inventory
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/apzuk3/go-eventbrite"
+	"github.com/apzuk3/go-eventbrite/eventbritetest"
+
+	"golang.org/x/net/context"
+)
+
+func TestPlan(t *testing.T) {
+	tests := []struct {
+		name     string
+		capacity int
+		classes  []eventbrite.TicketClass
+		ladder   Ladder
+		wantErr  string
+		// the names of the tiers changed by the plan
+		want []string
+	}{
+		{
+			name: "create",
+			ladder: Ladder{
+				{Name: "Early bird", QuantityTotal: 50, Cost: "USD,2500"},
+				{Name: "Regular", QuantityTotal: 150, Cost: "USD,4000"},
+			},
+			want: []string{"Early bird", "Regular"},
+		},
+		{
+			name:     "capacity counts the untouched classes",
+			capacity: 200,
+			classes:  []eventbrite.TicketClass{{Name: "VIP", QuantityTotal: 20, Free: true}},
+			ladder: Ladder{
+				{Name: "Early bird", QuantityTotal: 50, Cost: "USD,2500"},
+				{Name: "Regular", QuantityTotal: 150, Cost: "USD,4000"},
+			},
+			wantErr: "220 tickets",
+		},
+		{
+			name:     "within capacity",
+			capacity: 220,
+			classes:  []eventbrite.TicketClass{{Name: "VIP", QuantityTotal: 20, Free: true}},
+			ladder: Ladder{
+				{Name: "Early bird", QuantityTotal: 50, Cost: "USD,2500"},
+				{Name: "Regular", QuantityTotal: 150, Cost: "USD,4000"},
+			},
+			want: []string{"Early bird", "Regular"},
+		},
+		{
+			name:    "sold tickets",
+			classes: []eventbrite.TicketClass{{Name: "Regular", QuantityTotal: 100, QuantitySold: 80, Free: true}},
+			ladder:  Ladder{{Name: "Regular", QuantityTotal: 50}},
+			wantErr: "already sold",
+		},
+		{
+			name:    "duplicate tier",
+			ladder:  Ladder{{Name: "Regular"}, {Name: "Regular"}},
+			wantErr: "declared twice",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			inv := &Inventory{Event: eventbrite.Event{Id: "1", Capacity: tt.capacity}, Classes: tt.classes}
+
+			plan, err := inv.Plan(tt.ladder)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("Plan() error = %v, want %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			var got []string
+			for _, ch := range plan {
+				got = append(got, ch.Name)
+			}
+			if strings.Join(got, ",") != strings.Join(tt.want, ",") {
+				t.Errorf("Plan() changes %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+// TestApplyReorder moves the second tier of a ladder first: its link to the former first tier
+// must be cleared, after which the ladder is up to date
+func TestApplyReorder(t *testing.T) {
+	srv := eventbritetest.NewServer("token")
+	defer srv.Close()
+	clnt, err := srv.Client()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	eventID := srv.AddEvent(eventbrite.Event{})
+	early := srv.AddTicketClass(eventID, eventbrite.TicketClass{Name: "Early bird", QuantityTotal: 50, Free: true})
+	srv.AddTicketClass(eventID, eventbrite.TicketClass{Name: "Regular", QuantityTotal: 150, Free: true, SalesStartAfter: early})
+
+	ctx := context.Background()
+	inv, err := Load(ctx, clnt, eventID)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ladder := Ladder{
+		{Name: "Regular", QuantityTotal: 150},
+		{Name: "Early bird", QuantityTotal: 50},
+	}
+	plan, err := inv.Plan(ladder)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(plan) != 2 {
+		t.Fatalf("Plan() = %+v, want both tiers changed", plan)
+	}
+	if s := plan[0].Fields.SalesStartAfter; s == nil || *s != "" {
+		t.Errorf("first tier sales_start_after = %v, want an explicit clear", s)
+	}
+	if _, err := inv.Apply(ctx, clnt, plan); err != nil {
+		t.Fatal(err)
+	}
+
+	inv, err = Load(ctx, clnt, eventID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if regular := inv.byName("Regular"); regular.SalesStartAfter != "" {
+		t.Errorf("Regular goes on sale after %q, want on its own", regular.SalesStartAfter)
+	}
+	if early := inv.byName("Early bird"); early.SalesStartAfter != inv.byName("Regular").ID {
+		t.Errorf("Early bird goes on sale after %q, want after Regular", early.SalesStartAfter)
+	}
+
+	plan, err = inv.Plan(ladder)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(plan) != 0 {
+		t.Errorf("Plan() after Apply = %+v, want no change", plan)
+	}
+}
@@ -0,0 +1,207 @@
+package eventbrite
+
+import (
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"net/url"
+	"strings"
+	"time"
+
+	"golang.org/x/net/context"
+)
+
+// redacted replaces the personal data and the secrets in the logs
+const redacted = "[REDACTED]"
+
+// redactedKeys are the query parameters and payload fields removed from the logs, wherever they
+// appear. Dotted keys like attendee.profile.email are matched on their last segment.
+var redactedKeys = map[string]bool{
+	"token":      true,
+	"email":      true,
+	"first_name": true,
+	"last_name":  true,
+	"cell_phone": true,
+	"work_phone": true,
+	"home_phone": true,
+	"phone":      true,
+	"barcode":    true,
+}
+
+// personKeys mark the objects describing a person, like the profile of an attendee, an order or
+// a contact, whose name is redacted as well
+var personKeys = []string{"email", "first_name", "last_name"}
+
+// WithLogger configures the client to log every attempt of its calls with h: the operation, the
+// method, the path, the query, the attempt number, the status, the duration and the error key.
+// Successful attempts are logged at the info level and failed ones at the error level. When h
+// is enabled at the debug level, the request body and the decoded response are logged too.
+//
+// The token, the emails, the names, the phone numbers and the barcodes are redacted from the
+// query, the payloads and the errors, like those of attendees, orders and contacts.
+//
+//	clnt, err := eventbrite.NewClient(
+//		eventbrite.WithToken(token),
+//		eventbrite.WithLogger(slog.NewJSONHandler(os.Stderr, nil)),
+//	)
+//
+// The logger is a Middleware, it sees the attempts made by the middlewares added before it.
+func WithLogger(h slog.Handler) ClientOption {
+	return WithMiddleware(logging(slog.New(h)))
+}
+
+func logging(logger *slog.Logger) Middleware {
+	return func(next Handler) Handler {
+		return func(ctx context.Context, req *Request) (*Response, error) {
+			start := time.Now()
+			resp, err := next(ctx, req)
+
+			attrs := []slog.Attr{
+				slog.String("method", req.Method),
+				slog.String("path", req.Path),
+			}
+			if req.Operation != "" {
+				attrs = append(attrs, slog.String("operation", req.Operation))
+			}
+			if q := redactedQuery(req); q != "" {
+				attrs = append(attrs, slog.String("query", q))
+			}
+			attrs = append(attrs, slog.Int("attempt", req.Attempt))
+			if resp != nil {
+				attrs = append(attrs, slog.Int("status", resp.StatusCode))
+			}
+			attrs = append(attrs, slog.Duration("duration", time.Since(start)))
+
+			level := slog.LevelInfo
+			if err != nil {
+				level = slog.LevelError
+				attrs = append(attrs, logError(err)...)
+			}
+
+			if logger.Enabled(ctx, slog.LevelDebug) {
+				if req.Method != "GET" && req.Params != nil {
+					attrs = append(attrs, slog.Any("body", payload{req.Params}))
+				}
+				if err == nil && resp != nil && resp.Result != nil {
+					attrs = append(attrs, slog.Any("response", payload{resp.Result}))
+				}
+			}
+
+			logger.LogAttrs(ctx, level, "eventbrite call", attrs...)
+			return resp, err
+		}
+	}
+}
+
+// redactedQuery returns the query of a GET request, encoded from its params, without its
+// personal data
+func redactedQuery(req *Request) string {
+	if req.Method != "GET" || req.Params == nil {
+		return ""
+	}
+	values, err := encodeQuery(req.Params)
+	if err != nil {
+		return ""
+	}
+	return redactValues(values).Encode()
+}
+
+// logError returns the attributes describing err: the error key of an API error, the message of
+// any other error, with the URL of a transport error redacted
+func logError(err error) []slog.Attr {
+	var apiErr *Error
+	if errors.As(err, &apiErr) {
+		key := apiErr.Err
+		if key == "" {
+			key = apiErr.Description
+		}
+		return []slog.Attr{slog.String("error", key)}
+	}
+
+	var urlErr *url.Error
+	if errors.As(err, &urlErr) {
+		e := *urlErr
+		if u, perr := url.Parse(e.URL); perr == nil {
+			u.RawQuery = redactValues(u.Query()).Encode()
+			e.URL = u.String()
+		} else {
+			e.URL = redacted
+		}
+		return []slog.Attr{slog.String("error", e.Error())}
+	}
+	return []slog.Attr{slog.String("error", err.Error())}
+}
+
+// redactValues returns a copy of values without its personal data
+func redactValues(values url.Values) url.Values {
+	res := url.Values{}
+	for k, v := range values {
+		if redactedKey(k) {
+			res[k] = []string{redacted}
+			continue
+		}
+		res[k] = v
+	}
+	return res
+}
+
+func redactedKey(key string) bool {
+	if i := strings.LastIndexByte(key, '.'); i >= 0 {
+		key = key[i+1:]
+	}
+	return redactedKeys[key]
+}
+
+// payload is a request or response logged as JSON, without its personal data
+type payload struct {
+	v interface{}
+}
+
+// LogValue implements slog.LogValuer. The payload is encoded only when the record is logged.
+func (p payload) LogValue() slog.Value {
+	data, err := json.Marshal(p.v)
+	if err != nil {
+		return slog.StringValue(err.Error())
+	}
+
+	var v interface{}
+	if err := json.Unmarshal(data, &v); err != nil {
+		return slog.StringValue(err.Error())
+	}
+	data, err = json.Marshal(redactJSON(v))
+	if err != nil {
+		return slog.StringValue(err.Error())
+	}
+	return slog.AnyValue(json.RawMessage(data))
+}
+
+// redactJSON removes the personal data from a decoded JSON value, in place
+func redactJSON(v interface{}) interface{} {
+	switch v := v.(type) {
+	case map[string]interface{}:
+		person := false
+		for k := range v {
+			for _, pk := range personKeys {
+				if k == pk || strings.HasSuffix(k, "."+pk) {
+					person = true
+				}
+			}
+		}
+
+		for k, x := range v {
+			name := k == "name" || strings.HasSuffix(k, ".name")
+			if redactedKey(k) || (person && name) {
+				if x != nil && x != "" {
+					v[k] = redacted
+				}
+				continue
+			}
+			v[k] = redactJSON(x)
+		}
+	case []interface{}:
+		for i, x := range v {
+			v[i] = redactJSON(x)
+		}
+	}
+	return v
+}
@@ -0,0 +1,163 @@
+package eventbrite
+
+import (
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"sync"
+	"testing"
+
+	"golang.org/x/net/context"
+)
+
+// captureHandler is a slog.Handler keeping the attributes of every record, at every level
+type captureHandler struct {
+	mu      sync.Mutex
+	records []map[string]string
+}
+
+func (h *captureHandler) Enabled(context.Context, slog.Level) bool { return true }
+
+func (h *captureHandler) Handle(_ context.Context, r slog.Record) error {
+	attrs := map[string]string{}
+	r.Attrs(func(a slog.Attr) bool {
+		v := a.Value.Resolve()
+		if raw, ok := v.Any().(json.RawMessage); ok {
+			attrs[a.Key] = string(raw)
+		} else {
+			attrs[a.Key] = v.String()
+		}
+		return true
+	})
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.records = append(h.records, attrs)
+	return nil
+}
+
+func (h *captureHandler) WithAttrs([]slog.Attr) slog.Handler { return h }
+
+func (h *captureHandler) WithGroup(string) slog.Handler { return h }
+
+func TestLoggerRedaction(t *testing.T) {
+	const (
+		attendee = `{"id":"a1","profile":{"name":"Ada Lovelace","email":"ada@example.com","first_name":"Ada",` +
+			`"last_name":"Lovelace","cell_phone":"+44 20 7946 0000"},"barcodes":[{"barcode":"8713502920"}]}`
+		contact = `{"name":"Grace Hopper","email":"grace@example.com","first_name":"Grace","last_name":"Hopper"}`
+	)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/events/1/attendees/":
+			fmt.Fprintf(w, `{"pagination":{"page_number":1},"attendees":[%s]}`, attendee)
+		case "/users/me/contact_lists/7/contacts/":
+			fmt.Fprintf(w, `{"pagination":{"page_number":1},"contacts":[%s]}`, contact)
+		default:
+			fmt.Fprint(w, `{"id":"1"}`)
+		}
+	}))
+	defer srv.Close()
+
+	secrets := []string{
+		"secret-token", "leaked-token",
+		"Ada", "Lovelace", "ada@example.com", "7946", "8713502920",
+		"Grace", "Hopper", "grace@example.com",
+	}
+
+	tests := []struct {
+		name    string
+		baseURL string
+		call    func(clnt *Client) error
+		// the attributes which must hold a redacted value
+		want []string
+	}{
+		{
+			name: "token in the query",
+			call: func(clnt *Client) error {
+				return clnt.Resolve(context.Background(), srv.URL+"/events/1/?token=leaked-token", new(Event))
+			},
+			want: []string{"query"},
+		},
+		{
+			name: "attendees in the response",
+			call: func(clnt *Client) error {
+				_, err := clnt.EventAttendees(context.Background(), "1", nil)
+				return err
+			},
+			want: []string{"response"},
+		},
+		{
+			name: "contacts in the response",
+			call: func(clnt *Client) error {
+				_, err := clnt.UserListContactContacts(context.Background(), "me", "7", nil)
+				return err
+			},
+			want: []string{"response"},
+		},
+		{
+			name: "contact in the body",
+			call: func(clnt *Client) error {
+				_, err := clnt.UserListContactAddContacts(context.Background(), "me", "7", &UserAddContactListContactRequest{
+					Email:     "grace@example.com",
+					FirstName: "Grace",
+					LastName:  "Hopper",
+				})
+				return err
+			},
+			want: []string{"body", "response"},
+		},
+		{
+			name:    "url of a transport error",
+			baseURL: "http://127.0.0.1:1",
+			call: func(clnt *Client) error {
+				_, err := clnt.EventGet(context.Background(), "1")
+				if err == nil {
+					return fmt.Errorf("EventGet() succeeded against a closed port")
+				}
+				return nil
+			},
+			want: []string{"error"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			baseURL := tt.baseURL
+			if baseURL == "" {
+				baseURL = srv.URL
+			}
+
+			h := &captureHandler{}
+			clnt, err := NewClient(WithToken("secret-token"), WithBaseURL(baseURL), WithRateLimit(0),
+				WithRetryPolicy(RetryPolicy{MaxAttempts: 1}), WithLogger(h))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if err := tt.call(clnt); err != nil {
+				t.Fatal(err)
+			}
+
+			if len(h.records) != 1 {
+				t.Fatalf("logged %d records, want 1", len(h.records))
+			}
+			attrs := h.records[0]
+			for k, v := range attrs {
+				for _, s := range secrets {
+					if strings.Contains(v, s) {
+						t.Errorf("%s = %s, leaks %q", k, v, s)
+					}
+				}
+			}
+			for _, k := range tt.want {
+				// the query and the URL are encoded, the payloads are JSON
+				if v := attrs[k]; !strings.Contains(v, redacted) && !strings.Contains(v, url.QueryEscape(redacted)) {
+					t.Errorf("%s = %q, want %s values", k, v, redacted)
+				}
+			}
+		})
+	}
+}
@@ -1,8 +1,25 @@
 package eventbrite
 
 import (
+	"errors"
 	"fmt"
+	"io"
+	"io/ioutil"
+	"mime/multipart"
+	"net/http"
+
 	"golang.org/x/net/context"
+	"golang.org/x/net/context/ctxhttp"
+)
+
+// Types of image accepted by MediaGet and UploadImage
+const (
+	ImageEventLogo                = "image-event-logo"
+	ImageEventLogoPreserveQuality = "image-event-logo-preserve-quality"
+	ImageEventViewFromSeat        = "image-event-view-from-seat"
+	ImageOrganizerLogo            = "image-organizer-logo"
+	ImageUserPhoto                = "image-user-photo"
+	ImageStructuredContent        = "image-structured-content"
 )
 
 // https://www.eventbrite.com/developer/v3/resources/uploads/
@@ -12,18 +29,19 @@ type Media struct {
 	// oauth token
 	Token string `json:"upload_token"`
 	// the URL that should be uploaded to
-	Url string `json:"url"`
+	Url string `json:"upload_url"`
 	// the POST data that should be included in the POST that uploads the file
 	UploadData UploadData `json:"upload_data"`
 	// Specifies the POST field that the file itself should be included in (handled using HTTP multipart upload)
 	FileParameterName string `json:"file_parameter_name"`
 }
 
+// UploadData holds the fields of the POST uploading a file, sent before the file itself
 type UploadData struct {
 	AWSAccessKeyID string `json:"AWSAccessKeyId"`
 	Bucket         string `json:"bucket"`
 	Acl            string `json:"acl"`
-	Key            string `json:"kcl"`
+	Key            string `json:"key"`
 	Signature      string `json:"signature"`
 	Policy         string `json:"policy"`
 }
@@ -39,14 +57,24 @@ type MediaGetUpload struct {
 type MediaCreateUpload struct {
 	// The upload_token from the GET portion of the upload
 	UploadToken string `json:"upload_token" validate:"required"`
-	// X coordinate for top-left corner of crop mask
-	TopLeftX int `json:"crop_mask.top_left.x"`
-	// Y coordinate for top-left corner of crop mask
-	TopLeftY int `json:"crop_mask.top_left.y"`
+	// The part of the image to keep, the whole image when nil
+	CropMask *CropMask `json:"crop_mask,omitempty"`
+}
+
+// CropMask is the part of an uploaded image to keep
+type CropMask struct {
+	// The top-left corner of the crop mask
+	TopLeft CropMaskPoint `json:"top_left"`
 	// Crop mask width
-	Width int `json:"crop_mask.width"`
+	Width int `json:"width"`
 	// Crop mask height
-	Height int `json:"crop_mask.height"`
+	Height int `json:"height"`
+}
+
+// CropMaskPoint is a point of an image, in pixels from its top-left corner
+type CropMaskPoint struct {
+	X int `json:"x"`
+	Y int `json:"y"`
 }
 
 // https://www.eventbrite.com/developer/v3/endpoints/media/#ebapi-get-media-upload
@@ -54,7 +82,7 @@ type MediaCreateUpload struct {
 func (c *Client) MediaGet(ctx context.Context, req *MediaGetUpload) (*Media, error) {
 	m := new(Media)
 
-	return m, c.getJSON(ctx, "/media/upload/", req, m)
+	return m, c.getJSON(ctx, "MediaGet", "/media/upload/", req, m)
 }
 
 // Return an image for a given id
@@ -63,12 +91,109 @@ func (c *Client) MediaGet(ctx context.Context, req *MediaGetUpload) (*Media, err
 func (c *Client) MediaGetUpload(ctx context.Context, id string) (*Image, error) {
 	i := new(Image)
 
-	return i, c.getJSON(ctx, fmt.Sprintf("/media/%s/", id), nil, i)
+	return i, c.getJSON(ctx, "MediaGetUpload", fmt.Sprintf("/media/%s/", id), nil, i)
 }
 
+// MediaCreate notifies Eventbrite that the file of an upload has been sent, and returns the
+// resulting image
+//
 // https://www.eventbrite.com/developer/v3/endpoints/media/#ebapi-post-media-upload
 func (c *Client) MediaCreate(ctx context.Context, req *MediaCreateUpload) (*Image, error) {
 	i := new(Image)
 
-	return i, c.getJSON(ctx, fmt.Sprintf("/media/upload/"), req, i)
+	return i, c.postJSON(ctx, "MediaCreate", "/media/upload/", req, i)
+}
+
+// UploadImage runs the whole upload of an image of the given type: it fetches the upload
+// instructions, streams the content of r to the signed URL they point to, then notifies
+// Eventbrite with the upload token and the crop mask, which may be nil.
+//
+//	f, err := os.Open("logo.png")
+//	img, err := clnt.UploadImage(ctx, eventbrite.ImageEventLogo, f, nil)
+//	_, err = clnt.EventUpdate(ctx, "123", &eventbrite.EventUpdateRequest{Event: eventbrite.EventFields{LogoID: img.ID}})
+//
+// The upload itself is neither rate limited nor retried, since r can only be read once.
+func (c *Client) UploadImage(ctx context.Context, imageType string, r io.Reader, crop *CropMask) (*Image, error) {
+	m, err := c.MediaGet(ctx, &MediaGetUpload{Type: imageType})
+	if err != nil {
+		return nil, err
+	}
+	if m.Url == "" {
+		return nil, errors.New("eventbrite: upload instructions without upload_url")
+	}
+
+	if err := c.upload(ctx, m, r); err != nil {
+		return nil, err
+	}
+
+	return c.MediaCreate(ctx, &MediaCreateUpload{UploadToken: m.Token, CropMask: crop})
+}
+
+// upload streams the content of r to the URL of the upload instructions, as a multipart form
+// holding the upload data followed by the file. The token is not sent, the URL being signed.
+func (c *Client) upload(ctx context.Context, m *Media, r io.Reader) error {
+	pr, pw := io.Pipe()
+	mw := multipart.NewWriter(pw)
+
+	go func() {
+		pw.CloseWithError(writeUploadForm(mw, m, r))
+	}()
+
+	method := m.Method
+	if method == "" {
+		method = http.MethodPost
+	}
+	req, err := http.NewRequest(method, m.Url, pr)
+	if err != nil {
+		pr.Close()
+		return err
+	}
+	req.Header.Set("Content-Type", mw.FormDataContentType())
+
+	resp, err := ctxhttp.Do(ctx, c.httpClient, req)
+	pr.Close()
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		body, _ := ioutil.ReadAll(io.LimitReader(resp.Body, 1024))
+		return fmt.Errorf("eventbrite: upload to %s: %s: %s", req.URL.Host, resp.Status, body)
+	}
+	return nil
+}
+
+func writeUploadForm(mw *multipart.Writer, m *Media, r io.Reader) error {
+	fields := []struct {
+		name, value string
+	}{
+		{"AWSAccessKeyId", m.UploadData.AWSAccessKeyID},
+		{"bucket", m.UploadData.Bucket},
+		{"acl", m.UploadData.Acl},
+		{"key", m.UploadData.Key},
+		{"signature", m.UploadData.Signature},
+		{"policy", m.UploadData.Policy},
+	}
+	for _, f := range fields {
+		if f.value == "" {
+			continue
+		}
+		if err := mw.WriteField(f.name, f.value); err != nil {
+			return err
+		}
+	}
+
+	name := m.FileParameterName
+	if name == "" {
+		name = "file"
+	}
+	w, err := mw.CreateFormFile(name, "image")
+	if err != nil {
+		return err
+	}
+	if _, err := io.Copy(w, r); err != nil {
+		return err
+	}
+	return mw.Close()
 }
@@ -0,0 +1,85 @@
+package eventbrite
+
+import (
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"golang.org/x/net/context"
+)
+
+func TestUploadImage(t *testing.T) {
+	tests := []struct {
+		name      string
+		uploadURL bool
+		wantErr   string
+	}{
+		{name: "upload", uploadURL: true},
+		{name: "no upload url", uploadURL: false, wantErr: "without upload_url"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var uploaded, created bool
+			var srv *httptest.Server
+			srv = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				switch {
+				case r.Method == http.MethodGet && r.URL.Path == "/media/upload/":
+					uploadURL := ""
+					if tt.uploadURL {
+						uploadURL = srv.URL + "/bucket"
+					}
+					fmt.Fprintf(w, `{"upload_method":"POST","upload_token":"tok","upload_url":%q,"upload_data":{"key":"k1"},"file_parameter_name":"file"}`, uploadURL)
+				case r.URL.Path == "/bucket":
+					if r.URL.Query().Get("token") != "" {
+						t.Error("the token was sent to the upload url")
+					}
+					if r.FormValue("key") != "k1" {
+						t.Errorf("key = %q, want k1", r.FormValue("key"))
+					}
+					f, _, err := r.FormFile("file")
+					if err != nil {
+						t.Error(err)
+						return
+					}
+					content, _ := ioutil.ReadAll(f)
+					if string(content) != "png" {
+						t.Errorf("file = %q, want png", content)
+					}
+					uploaded = true
+				case r.Method == http.MethodPost && r.URL.Path == "/media/upload/":
+					created = true
+					fmt.Fprint(w, `{"id":"9","url":"https://img/9"}`)
+				default:
+					http.NotFound(w, r)
+				}
+			}))
+			defer srv.Close()
+
+			clnt, err := NewClient(WithToken("secret"), WithBaseURL(srv.URL), WithRateLimit(0))
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			img, err := clnt.UploadImage(context.Background(), ImageEventLogo, strings.NewReader("png"), nil)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("UploadImage() error = %v, want %q", err, tt.wantErr)
+				}
+				if uploaded || created {
+					t.Error("the upload went on without an upload url")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !uploaded || !created || img.ID != "9" {
+				t.Errorf("uploaded = %v, created = %v, image = %+v", uploaded, created, img)
+			}
+		})
+	}
+}
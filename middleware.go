@@ -0,0 +1,248 @@
+package eventbrite
+
+import (
+	"bytes"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"time"
+
+	"golang.org/x/net/context"
+	"golang.org/x/net/context/ctxhttp"
+)
+
+// Request describes an attempt of a call to the Eventbrite API, as seen by a Middleware
+type Request struct {
+	// The HTTP method
+	Method string
+	// The API path, without the base URL nor the query, like /events/123/
+	Path string
+	// The path with its IDs replaced by {id}, like /events/{id}/
+	Route string
+	// The Client method making the call, like EventGet or UserOrders
+	Operation string
+	// The request struct, sent as the query of a GET or the JSON body of a POST, nil when there
+	// is none. It is encoded once before the first attempt, changing it has no effect.
+	Params interface{}
+	// The headers added to the HTTP request, middlewares may set their own
+	Header http.Header
+	// The value the response body is decoded into, the one returned to the caller of the Client
+	// method. A middleware answering without calling next fills it itself.
+	Result interface{}
+	// The attempt number, 1 for the first one and increased on every retry
+	Attempt int
+}
+
+// Response is the outcome of an attempt of a call to the Eventbrite API, as seen by a Middleware
+type Response struct {
+	// The HTTP status code
+	StatusCode int
+	// The response headers
+	Header http.Header
+	// The value the response body is decoded into, Request.Result. It is left untouched when
+	// the attempt fails.
+	Result interface{}
+
+	// the raw body of a successful response, kept for the cache
+	body []byte
+}
+
+// Handler makes an attempt of a call. It returns the response along with an *Error when
+// Eventbrite answers with an error status, and a nil response when no answer was received.
+// Only the attempts failing with a retryable status or an error of the HTTP transport are
+// retried, any other error ends the call.
+type Handler func(ctx context.Context, req *Request) (*Response, error)
+
+// Middleware wraps the Handler making every attempt of a call, to add headers, log, refresh
+// credentials or record metrics. It may call next any number of times, or answer on its own
+// by decoding its answer into req.Result and returning a response with a 2xx status.
+//
+//	timing := func(next eventbrite.Handler) eventbrite.Handler {
+//		return func(ctx context.Context, req *eventbrite.Request) (*eventbrite.Response, error) {
+//			start := time.Now()
+//			resp, err := next(ctx, req)
+//			log.Printf("%s %s #%d took %s: %v", req.Method, req.Path, req.Attempt, time.Since(start), err)
+//			return resp, err
+//		}
+//	}
+type Middleware func(next Handler) Handler
+
+// WithMiddleware configures the client to pass every attempt of its calls through the given
+// middlewares. The first one is the outermost: it sees the request first and the response last.
+// Middlewares added by several options are chained in the order of the options.
+func WithMiddleware(mw ...Middleware) ClientOption {
+	return func(c *Client) error {
+		c.middlewares = append(c.middlewares, mw...)
+		return nil
+	}
+}
+
+// Call describes a call to the Eventbrite API, made of one attempt or more, as seen by a
+// CallObserver
+type Call struct {
+	// The Client method making the call, like EventGet or UserOrders
+	Operation string
+	// The HTTP method
+	Method string
+	// The API path, without the base URL nor the query, like /events/123/
+	Path string
+	// The path with its IDs replaced by {id}, like /events/{id}/
+	Route string
+	// The request struct, nil when there is none
+	Params interface{}
+
+	// The number of attempts made, set when the call ends
+	Attempts int
+	// The HTTP status of the last response, zero when none was received
+	StatusCode int
+	// The time spent waiting for the rate limiter, over every attempt
+	LimiterWait time.Duration
+}
+
+// CallObserver is notified of the calls of a client, to trace them or record metrics. Unlike
+// a Middleware it sees a call once, however many attempts it takes. A call served by the
+// cache without a request is not observed.
+type CallObserver interface {
+	// StartCall is called before the first attempt. The context it returns is the one of the
+	// attempts, and the one given to EndCall.
+	StartCall(ctx context.Context, call *Call) context.Context
+	// EndCall is called once the call succeeded or failed with err
+	EndCall(ctx context.Context, call *Call, err error)
+}
+
+// WithCallObserver configures the client to notify o of its calls. Observers added by several
+// options are started in the order of the options and ended in the reverse order.
+func WithCallObserver(o CallObserver) ClientOption {
+	return func(c *Client) error {
+		c.observers = append(c.observers, o)
+		return nil
+	}
+}
+
+// call makes a call through the middlewares, retrying it according to the client retry policy.
+// newRequest builds the HTTP request of an attempt, whose response body is decoded into
+// req.Result. It returns the response of the last attempt along with the number of attempts made.
+func (c *Client) call(ctx context.Context, req *Request, newRequest func() (*http.Request, error)) (resp *Response, attempts int, err error) {
+	req.Route = route(req.Path)
+	call := &Call{
+		Operation: req.Operation,
+		Method:    req.Method,
+		Path:      req.Path,
+		Route:     req.Route,
+		Params:    req.Params,
+	}
+	for _, o := range c.observers {
+		ctx = o.StartCall(ctx, call)
+	}
+	defer func() {
+		call.Attempts = attempts
+		if resp != nil {
+			call.StatusCode = resp.StatusCode
+		}
+		for i := len(c.observers) - 1; i >= 0; i-- {
+			c.observers[i].EndCall(ctx, call, err)
+		}
+	}()
+
+	h := c.send(newRequest, call)
+	for i := len(c.middlewares) - 1; i >= 0; i-- {
+		h = c.middlewares[i](h)
+	}
+
+	for attempt := 1; ; attempt++ {
+		req.Attempt = attempt
+		resp, err := h(ctx, req)
+		if err != nil && ctx.Err() != nil {
+			return resp, attempt, ctx.Err()
+		}
+		if !c.retryPolicy.shouldRetry(req.Method, attempt, resp, err) {
+			return resp, attempt, unwrapTransport(err)
+		}
+
+		if err := sleep(ctx, c.retryPolicy.backoff(attempt, resp)); err != nil {
+			return nil, attempt, err
+		}
+	}
+}
+
+// send returns the innermost Handler, which waits for the rate limiter, sends the HTTP request
+// built by newRequest and decodes its response into req.Result. The time spent waiting for the
+// limiter is added to call.
+func (c *Client) send(newRequest func() (*http.Request, error), call *Call) Handler {
+	return func(ctx context.Context, req *Request) (*Response, error) {
+		start := time.Now()
+		err := c.awaitRateLimiter(ctx)
+		call.LimiterWait += time.Since(start)
+		if err != nil {
+			return nil, err
+		}
+
+		httpReq, err := newRequest()
+		if err != nil {
+			return nil, err
+		}
+		for k, v := range req.Header {
+			httpReq.Header[k] = v
+		}
+		if err := c.authorize(httpReq); err != nil {
+			return nil, err
+		}
+
+		httpResp, err := ctxhttp.Do(ctx, c.httpClient, httpReq)
+		if err != nil {
+			return nil, &transportError{err}
+		}
+		body := httpResp.Body
+		defer func() {
+			io.Copy(ioutil.Discard, body)
+			body.Close()
+		}()
+		c.observeRateLimit(httpResp)
+
+		resp := &Response{StatusCode: httpResp.StatusCode, Header: httpResp.Header, Result: req.Result}
+		switch {
+		case httpResp.StatusCode == http.StatusNotModified:
+			return resp, nil
+		case httpResp.StatusCode >= 200 && httpResp.StatusCode < 300:
+			if resp.body, err = ioutil.ReadAll(body); err != nil {
+				return resp, err
+			}
+			httpResp.Body = ioutil.NopCloser(bytes.NewReader(resp.body))
+		}
+		return resp, decodeResponse(req.Method, req.Path, httpResp, req.Result)
+	}
+}
+
+// transportError is an error of the HTTP transport, which may be retried. Middlewares see it
+// wrapping the error of the transport, callers get the latter.
+type transportError struct {
+	err error
+}
+
+func (e *transportError) Error() string {
+	return e.err.Error()
+}
+
+func (e *transportError) Unwrap() error {
+	return e.err
+}
+
+// unwrapTransport returns the error of the transport wrapped by err, if any
+func unwrapTransport(err error) error {
+	if te, ok := err.(*transportError); ok {
+		return te.err
+	}
+	return err
+}
+
+// route returns the path with the segments holding an ID replaced by {id}
+func route(path string) string {
+	segments := strings.Split(path, "/")
+	for i, s := range segments {
+		if strings.IndexAny(s, "0123456789") >= 0 {
+			segments[i] = "{id}"
+		}
+	}
+	return strings.Join(segments, "/")
+}
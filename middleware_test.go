@@ -0,0 +1,177 @@
+package eventbrite
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"golang.org/x/net/context"
+	"golang.org/x/oauth2"
+)
+
+type failingTokenSource struct{}
+
+func (failingTokenSource) Token() (*oauth2.Token, error) {
+	return nil, errors.New("token revoked")
+}
+
+func TestMiddlewareChain(t *testing.T) {
+	var hits int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("X-Chain") != "outer,inner" {
+			t.Errorf("X-Chain = %q, want outer,inner", r.Header.Get("X-Chain"))
+		}
+		if atomic.AddInt32(&hits, 1) == 1 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		fmt.Fprint(w, `{"id":"42"}`)
+	}))
+	defer srv.Close()
+
+	var trace []string
+	mw := func(name string) Middleware {
+		return func(next Handler) Handler {
+			return func(ctx context.Context, req *Request) (*Response, error) {
+				if chain := req.Header.Get("X-Chain"); chain != "" && req.Attempt == 1 {
+					req.Header.Set("X-Chain", chain+","+name)
+				} else if req.Attempt == 1 {
+					req.Header.Set("X-Chain", name)
+				}
+				trace = append(trace, fmt.Sprintf("%s>%s#%d", name, req.Operation, req.Attempt))
+				resp, err := next(ctx, req)
+				status := 0
+				if resp != nil {
+					status = resp.StatusCode
+				}
+				trace = append(trace, fmt.Sprintf("%s<%d", name, status))
+				return resp, err
+			}
+		}
+	}
+
+	clnt, err := NewClient(
+		WithToken("token"),
+		WithBaseURL(srv.URL),
+		WithRateLimit(0),
+		WithRetryPolicy(RetryPolicy{MaxAttempts: 3, MinBackoff: time.Millisecond}),
+		WithMiddleware(mw("outer")),
+		WithMiddleware(mw("inner")),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	e, err := clnt.EventGet(context.Background(), "42")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if e.Id != "42" {
+		t.Errorf("event id = %q, want 42", e.Id)
+	}
+
+	want := "outer>EventGet#1 inner>EventGet#1 inner<503 outer<503 outer>EventGet#2 inner>EventGet#2 inner<200 outer<200"
+	if got := strings.Join(trace, " "); got != want {
+		t.Errorf("trace = %s\nwant    %s", got, want)
+	}
+}
+
+func TestMiddlewareAnswers(t *testing.T) {
+	answer := func(next Handler) Handler {
+		return func(ctx context.Context, req *Request) (*Response, error) {
+			if e, ok := req.Result.(**Event); ok {
+				(*e).Id = "from middleware"
+			}
+			return &Response{StatusCode: http.StatusOK, Result: req.Result}, nil
+		}
+	}
+
+	clnt, err := NewClient(WithToken("token"), WithBaseURL("http://127.0.0.1:1"), WithMiddleware(answer))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	e, err := clnt.EventGet(context.Background(), "42")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if e.Id != "from middleware" {
+		t.Errorf("event id = %q, want the one of the middleware", e.Id)
+	}
+}
+
+func TestRetriedErrors(t *testing.T) {
+	tests := []struct {
+		name         string
+		options      []ClientOption
+		baseURL      string
+		wantAttempts int
+		wantRetry    bool
+	}{
+		{
+			name:         "failing token source",
+			options:      []ClientOption{WithTokenSource(failingTokenSource{})},
+			wantAttempts: 1,
+		},
+		{
+			name:         "transport error",
+			options:      []ClientOption{WithToken("token")},
+			baseURL:      "http://127.0.0.1:1",
+			wantAttempts: 3,
+			wantRetry:    true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var attempts int32
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				fmt.Fprint(w, `{}`)
+			}))
+			defer srv.Close()
+
+			baseURL := tt.baseURL
+			if baseURL == "" {
+				baseURL = srv.URL
+			}
+			count := func(next Handler) Handler {
+				return func(ctx context.Context, req *Request) (*Response, error) {
+					atomic.AddInt32(&attempts, 1)
+					return next(ctx, req)
+				}
+			}
+			options := append([]ClientOption{
+				WithBaseURL(baseURL),
+				WithRateLimit(0),
+				WithRetryPolicy(RetryPolicy{MaxAttempts: 3, MinBackoff: time.Millisecond}),
+				WithMiddleware(count),
+			}, tt.options...)
+
+			clnt, err := NewClient(options...)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			_, err = clnt.EventGet(context.Background(), "42")
+			if err == nil {
+				t.Fatal("EventGet() succeeded, want an error")
+			}
+			if int(attempts) != tt.wantAttempts {
+				t.Errorf("attempts = %d, want %d", attempts, tt.wantAttempts)
+			}
+			var retryErr *RetryError
+			if errors.As(err, &retryErr) != tt.wantRetry {
+				t.Errorf("error = %v, want a RetryError: %v", err, tt.wantRetry)
+			}
+			var te *transportError
+			if errors.As(err, &te) {
+				t.Errorf("error = %#v, the transport error is not unwrapped", err)
+			}
+		})
+	}
+}
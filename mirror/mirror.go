@@ -0,0 +1,356 @@
+// Package mirror keeps a local SQLite copy of the events, ticket classes, orders, attendees,
+// venues and organizers of a user or an organization, so that questions like "how many
+// attendees of our live events bought a VIP ticket" are answered without calling the API.
+//
+//	m, err := mirror.Open(clnt, "me", "eventbrite.db")
+//	if err != nil {
+//		// handle me
+//	}
+//	defer m.Close()
+//
+//	if err := m.Refresh(ctx); err != nil {
+//		// handle me
+//	}
+//	n, err := m.Store().CountAttendees(ctx, mirror.AttendeeFilter{EventStatus: "live", TicketClassName: "VIP"})
+//
+// Orders and attendees are refreshed incrementally using the changed_since filter of the list
+// endpoints, the other objects are listed in full on every refresh. Objects deleted on
+// Eventbrite are kept in the mirror. The database uses the pure Go driver of modernc.org/sqlite,
+// registered as "sqlite".
+package mirror
+
+import (
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/apzuk3/go-eventbrite"
+
+	"golang.org/x/net/context"
+
+	_ "modernc.org/sqlite"
+)
+
+const (
+	// DefaultOverlap is how far before the cursor each incremental refresh starts, to catch
+	// objects whose change was committed after a more recent one had been listed
+	DefaultOverlap = time.Minute
+
+	// changedSinceLayout is the format of the changed_since parameter and of the time columns
+	changedSinceLayout = "2006-01-02T15:04:05Z"
+)
+
+// Keys of the incremental refresh cursors in the sync_state table
+const (
+	stateOrders    = "orders"
+	stateAttendees = "attendees"
+)
+
+// Mirror refreshes the local copy of the data of a user or an organization. The exported fields
+// must be set before the first refresh.
+type Mirror struct {
+	client *eventbrite.Client
+	userID string
+	db     *sql.DB
+	store  *Store
+
+	// Overlap is how far before the cursor each incremental refresh starts
+	Overlap time.Duration
+}
+
+// Open opens the mirror database at path, creating it along with its schema when missing. The ID
+// may be "me" for the owner of the client token, or the ID of an organization.
+func Open(client *eventbrite.Client, userID, path string) (*Mirror, error) {
+	db, err := sql.Open("sqlite", "file:"+path+"?_pragma=busy_timeout(5000)&_pragma=journal_mode(WAL)")
+	if err != nil {
+		return nil, err
+	}
+	// a single writer connection avoids SQLITE_BUSY between the refresh transactions
+	db.SetMaxOpenConns(1)
+
+	if _, err := db.Exec(schema); err != nil {
+		db.Close()
+		return nil, fmt.Errorf("mirror: create schema: %v", err)
+	}
+
+	store, err := OpenStore(path)
+	if err != nil {
+		db.Close()
+		return nil, err
+	}
+
+	return &Mirror{
+		client:  client,
+		userID:  userID,
+		db:      db,
+		store:   store,
+		Overlap: DefaultOverlap,
+	}, nil
+}
+
+// Store returns the read-only query API of the mirror
+func (m *Mirror) Store() *Store {
+	return m.store
+}
+
+// Close closes the database
+func (m *Mirror) Close() error {
+	err := m.store.Close()
+	if dbErr := m.db.Close(); err == nil {
+		err = dbErr
+	}
+	return err
+}
+
+// Refresh pulls the objects created or changed since the previous refresh. Each kind of object
+// is written in its own transaction along with its cursor, so a failed refresh leaves the mirror
+// consistent and the next one resumes from the last kind completed.
+func (m *Mirror) Refresh(ctx context.Context) error {
+	steps := []struct {
+		name string
+		run  func(ctx context.Context, tx *sql.Tx) error
+	}{
+		{"organizers", m.refreshOrganizers},
+		{"venues", m.refreshVenues},
+		{"events", m.refreshEvents},
+		{stateOrders, m.refreshOrders},
+		{stateAttendees, m.refreshAttendees},
+	}
+
+	for _, step := range steps {
+		if err := m.inTx(ctx, step.run); err != nil {
+			return fmt.Errorf("mirror: refresh %s: %w", step.name, err)
+		}
+	}
+	return nil
+}
+
+func (m *Mirror) inTx(ctx context.Context, f func(ctx context.Context, tx *sql.Tx) error) error {
+	tx, err := m.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	if err := f(ctx, tx); err != nil {
+		tx.Rollback()
+		return err
+	}
+	return tx.Commit()
+}
+
+func (m *Mirror) refreshOrganizers(ctx context.Context, tx *sql.Tx) error {
+	return m.client.UserOrganizersIterator(ctx, m.userID, nil).Walk(func(o eventbrite.Organizer) error {
+		return upsert(ctx, tx, "organizers", o,
+			column{"id", o.ID},
+			column{"name", o.Name},
+			column{"url", o.Url},
+		)
+	})
+}
+
+func (m *Mirror) refreshVenues(ctx context.Context, tx *sql.Tx) error {
+	return m.client.UserVenuesIterator(ctx, m.userID, nil).Walk(func(v eventbrite.Venue) error {
+		return upsert(ctx, tx, "venues", v,
+			column{"id", v.ID},
+			column{"name", v.Name},
+			column{"city", v.Address.City},
+			column{"region", v.Address.Region},
+			column{"country", v.Address.Country},
+			column{"postal_code", v.Address.PostalCode},
+		)
+	})
+}
+
+// refreshEvents lists every event, then the ticket classes of the events which changed since
+// the previous refresh or which are still selling tickets
+func (m *Mirror) refreshEvents(ctx context.Context, tx *sql.Tx) error {
+	changed, err := changedTimes(ctx, tx, "events")
+	if err != nil {
+		return err
+	}
+
+	var refresh []string
+	err = m.client.UserOwnedEventsIterator(ctx, m.userID, &eventbrite.UserOwnedEventsRequest{Status: "all"}).Walk(func(e eventbrite.Event) error {
+		prev, known := changed[e.Id]
+		if !known || prev != formatTime(e.Changed.Time) || e.Status == "live" || e.Status == "started" {
+			refresh = append(refresh, e.Id)
+		}
+
+		return upsert(ctx, tx, "events", e,
+			column{"id", e.Id},
+			column{"name", e.Name.Text},
+			column{"status", e.Status},
+			column{"start_utc", e.Start.Utc},
+			column{"end_utc", e.End.Utc},
+			column{"timezone", e.Start.Timezone},
+			column{"currency", e.Currency},
+			column{"online_event", e.OnlineEvent},
+			column{"venue_id", e.VenueId},
+			column{"organizer_id", e.OrganizerId},
+			column{"url", e.Url},
+			column{"created", formatTime(e.Created.Time)},
+			column{"changed", formatTime(e.Changed.Time)},
+		)
+	})
+	if err != nil {
+		return err
+	}
+
+	for _, id := range refresh {
+		if err := m.refreshTicketClasses(ctx, tx, id); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (m *Mirror) refreshTicketClasses(ctx context.Context, tx *sql.Tx, eventID string) error {
+	return m.client.EventTicketClassesIterator(ctx, eventID, nil).Walk(func(t eventbrite.TicketClass) error {
+		return upsert(ctx, tx, "ticket_classes", t,
+			column{"id", t.ID},
+			column{"event_id", eventID},
+			column{"name", t.Name},
+			column{"currency", string(t.Cost.Currency)},
+			column{"cost", int64(t.Cost.Value)},
+			column{"free", t.Free},
+			column{"quantity_total", t.QuantityTotal},
+			column{"quantity_sold", t.QuantitySold},
+		)
+	})
+}
+
+func (m *Mirror) refreshOrders(ctx context.Context, tx *sql.Tx) error {
+	return m.incremental(ctx, tx, stateOrders, func(since string, track func(time.Time)) error {
+		req := &eventbrite.UserEventOrdersRequest{ChangedSince: since}
+		return m.client.UserEventOrdersIterator(ctx, m.userID, req).Walk(func(o eventbrite.Order) error {
+			track(o.Changed.Time)
+			return upsert(ctx, tx, "orders", o,
+				column{"id", o.ID},
+				column{"event_id", o.EventID},
+				column{"name", o.Name},
+				column{"email", o.Email},
+				column{"currency", string(o.Costs.Gross.Currency)},
+				column{"gross", int64(o.Costs.Gross.Value)},
+				column{"created", formatTime(o.Created.Time)},
+				column{"changed", formatTime(o.Changed.Time)},
+			)
+		})
+	})
+}
+
+func (m *Mirror) refreshAttendees(ctx context.Context, tx *sql.Tx) error {
+	return m.incremental(ctx, tx, stateAttendees, func(since string, track func(time.Time)) error {
+		req := &eventbrite.UserEventAttendeesRequest{ChangedSince: since}
+		return m.client.UserEventAttendeesIterator(ctx, m.userID, req).Walk(func(a eventbrite.Attendee) error {
+			track(a.Changed.Time)
+			return upsert(ctx, tx, "attendees", a,
+				column{"id", a.ID},
+				column{"event_id", a.EventID},
+				column{"order_id", a.OrderID},
+				column{"ticket_class_id", a.TicketClassID},
+				column{"ticket_class_name", a.TicketClassName},
+				column{"name", a.Profile.Name},
+				column{"email", a.Profile.Email},
+				column{"status", a.Status},
+				column{"checked_in", a.CheckedIn},
+				column{"cancelled", a.Cancelled},
+				column{"refunded", a.Refunded},
+				column{"created", formatTime(a.Created.Time)},
+				column{"changed", formatTime(a.Changed.Time)},
+			)
+		})
+	})
+}
+
+// incremental runs list with the changed_since value of the cursor saved under key, every
+// object being listed when there is none, then saves the most recent change time tracked.
+// Rows are upserted by ID, so the objects listed again because of the overlap are harmless.
+func (m *Mirror) incremental(ctx context.Context, tx *sql.Tx, key string, list func(since string, track func(time.Time)) error) error {
+	var cursor string
+	err := tx.QueryRowContext(ctx, "SELECT changed_since FROM sync_state WHERE key = ?", key).Scan(&cursor)
+	if err != nil && err != sql.ErrNoRows {
+		return err
+	}
+
+	var next, since time.Time
+	if cursor != "" {
+		if next, err = time.Parse(changedSinceLayout, cursor); err != nil {
+			return fmt.Errorf("invalid cursor %q: %v", cursor, err)
+		}
+		since = next.Add(-m.Overlap)
+	}
+
+	var sinceParam string
+	if !since.IsZero() {
+		sinceParam = formatTime(since)
+	}
+
+	err = list(sinceParam, func(changed time.Time) {
+		if changed.After(next) {
+			next = changed
+		}
+	})
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.ExecContext(ctx,
+		"INSERT OR REPLACE INTO sync_state (key, changed_since, refreshed) VALUES (?, ?, ?)",
+		key, formatTime(next), formatTime(time.Now()))
+	return err
+}
+
+// column is a column of a row and its value
+type column struct {
+	name  string
+	value interface{}
+}
+
+// upsert inserts or replaces the row of an object, whose JSON encoding goes to the raw column
+func upsert(ctx context.Context, tx *sql.Tx, table string, object interface{}, columns ...column) error {
+	raw, err := json.Marshal(object)
+	if err != nil {
+		return err
+	}
+
+	names := make([]string, 0, len(columns)+1)
+	values := make([]interface{}, 0, len(columns)+1)
+	for _, c := range columns {
+		names = append(names, c.name)
+		values = append(values, c.value)
+	}
+	names = append(names, "raw")
+	values = append(values, string(raw))
+
+	query := fmt.Sprintf("INSERT OR REPLACE INTO %s (%s) VALUES (?%s)",
+		table, strings.Join(names, ", "), strings.Repeat(", ?", len(names)-1))
+	_, err = tx.ExecContext(ctx, query, values...)
+	return err
+}
+
+// changedTimes returns the changed column of every row of the table, keyed by ID
+func changedTimes(ctx context.Context, tx *sql.Tx, table string) (map[string]string, error) {
+	rows, err := tx.QueryContext(ctx, "SELECT id, changed FROM "+table)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	res := map[string]string{}
+	for rows.Next() {
+		var id, changed string
+		if err := rows.Scan(&id, &changed); err != nil {
+			return nil, err
+		}
+		res[id] = changed
+	}
+	return res, rows.Err()
+}
+
+func formatTime(t time.Time) string {
+	if t.IsZero() {
+		return ""
+	}
+	return t.UTC().Format(changedSinceLayout)
+}
@@ -0,0 +1,135 @@
+package mirror
+
+import (
+	"path/filepath"
+	"sort"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/apzuk3/go-eventbrite"
+	"github.com/apzuk3/go-eventbrite/eventbritetest"
+
+	"golang.org/x/net/context"
+)
+
+func TestRefresh(t *testing.T) {
+	ctx := context.Background()
+	srv := eventbritetest.NewServer("token")
+	defer srv.Close()
+	clnt, err := srv.Client()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	start := time.Date(2026, 7, 1, 18, 0, 0, 0, time.UTC)
+	at := func(d time.Duration) eventbrite.DateTime {
+		return eventbrite.DateTime{Time: start.Add(d)}
+	}
+	attendee := func(id, eventID, ticketClass string, changed time.Duration) eventbrite.Attendee {
+		return eventbrite.Attendee{ID: id, EventID: eventID, TicketClassName: ticketClass, Changed: at(changed)}
+	}
+
+	live := srv.AddEvent(eventbrite.Event{Id: "e1", Status: "live", Start: eventbrite.DatetimeTz{Utc: "2026-08-01T18:00:00Z"}})
+	ended := srv.AddEvent(eventbrite.Event{Id: "e2", Status: "ended", Start: eventbrite.DatetimeTz{Utc: "2026-06-01T18:00:00Z"}})
+	srv.AddTicketClass(live, eventbrite.TicketClass{ID: "t1", Name: "VIP"})
+	srv.AddOrder(eventbrite.Order{ID: "o1", EventID: live, Changed: at(0)})
+	srv.AddAttendee(attendee("a1", live, "VIP", 0))
+	srv.AddAttendee(attendee("a2", live, "General", 0))
+	srv.AddAttendee(attendee("a3", ended, "VIP", 0))
+
+	m, err := Open(clnt, "me", filepath.Join(t.TempDir(), "eventbrite.db"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer m.Close()
+	store := m.Store()
+
+	if last, err := store.LastRefresh(ctx); err != nil || !last.IsZero() {
+		t.Errorf("LastRefresh() before the first refresh = %v, %v, want the zero time", last, err)
+	}
+
+	before := time.Now().Truncate(time.Second)
+	if err := m.Refresh(ctx); err != nil {
+		t.Fatal(err)
+	}
+	if last, err := store.LastRefresh(ctx); err != nil || last.Before(before) || last.After(time.Now()) {
+		t.Errorf("LastRefresh() = %v, %v, want the time of the refresh", last, err)
+	}
+
+	events, err := store.Events(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var ids []string
+	for _, e := range events {
+		ids = append(ids, e.Id)
+	}
+	if strings.Join(ids, ",") != "e2,e1" {
+		t.Errorf("Events() = %v, want every event by start time", ids)
+	}
+	if events, err := store.Events(ctx, "live"); err != nil || len(events) != 1 || events[0].Id != live {
+		t.Errorf("Events(live) = %v, %v, want %s", events, err, live)
+	}
+	if tcs, err := store.TicketClasses(ctx, live); err != nil || len(tcs) != 1 || tcs[0].Name != "VIP" {
+		t.Errorf("TicketClasses() = %v, %v, want the VIP ticket class", tcs, err)
+	}
+
+	// the second refresh lists the attendees changed since the first one: a4 is new and a1
+	// was cancelled, but a0 changed before the overlap, so it is not listed
+	srv.AddAttendee(attendee("a0", live, "VIP", -time.Hour))
+	a1 := attendee("a1", live, "VIP", time.Hour)
+	a1.Cancelled = true
+	srv.AddAttendee(a1)
+	srv.AddAttendee(attendee("a4", live, "VIP", 2*time.Hour))
+	srv.AddAttendee(attendee("a5", ended, "VIP", 2*time.Hour))
+
+	if err := m.Refresh(ctx); err != nil {
+		t.Fatal(err)
+	}
+
+	attendees, err := store.Attendees(ctx, live)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ids = nil
+	for _, a := range attendees {
+		ids = append(ids, a.ID)
+	}
+	sort.Strings(ids)
+	if strings.Join(ids, ",") != "a1,a2,a4" {
+		t.Errorf("Attendees() = %v, want the changed attendees only", ids)
+	}
+
+	tests := []struct {
+		name   string
+		filter AttendeeFilter
+		want   int
+	}{
+		{name: "all", filter: AttendeeFilter{}, want: 4},
+		{name: "cancelled included", filter: AttendeeFilter{IncludeCancelled: true}, want: 5},
+		{name: "live VIP", filter: AttendeeFilter{EventStatus: "live", TicketClassName: "VIP"}, want: 1},
+		{name: "event", filter: AttendeeFilter{EventID: ended}, want: 2},
+		{name: "no match", filter: AttendeeFilter{EventStatus: "draft"}, want: 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			n, err := store.CountAttendees(ctx, tt.filter)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if n != tt.want {
+				t.Errorf("CountAttendees(%+v) = %d, want %d", tt.filter, n, tt.want)
+			}
+		})
+	}
+
+	var cursor string
+	if err := store.QueryRow(ctx, "SELECT changed_since FROM sync_state WHERE key = ?", stateAttendees).Scan(&cursor); err != nil {
+		t.Fatal(err)
+	}
+	if want := formatTime(start.Add(2 * time.Hour)); cursor != want {
+		t.Errorf("attendees cursor = %s, want %s", cursor, want)
+	}
+}
@@ -0,0 +1,91 @@
+package mirror
+
+// schema creates the tables of the mirror. Every table keeps the object as returned by the API
+// in its raw column, so fields without a column remain reachable through json_extract.
+const schema = `
+CREATE TABLE IF NOT EXISTS organizers (
+	id   TEXT PRIMARY KEY,
+	name TEXT NOT NULL,
+	url  TEXT NOT NULL,
+	raw  TEXT NOT NULL
+);
+
+CREATE TABLE IF NOT EXISTS venues (
+	id          TEXT PRIMARY KEY,
+	name        TEXT NOT NULL,
+	city        TEXT NOT NULL,
+	region      TEXT NOT NULL,
+	country     TEXT NOT NULL,
+	postal_code TEXT NOT NULL,
+	raw         TEXT NOT NULL
+);
+
+CREATE TABLE IF NOT EXISTS events (
+	id           TEXT PRIMARY KEY,
+	name         TEXT NOT NULL,
+	status       TEXT NOT NULL,
+	start_utc    TEXT NOT NULL,
+	end_utc      TEXT NOT NULL,
+	timezone     TEXT NOT NULL,
+	currency     TEXT NOT NULL,
+	online_event INTEGER NOT NULL,
+	venue_id     TEXT NOT NULL,
+	organizer_id TEXT NOT NULL,
+	url          TEXT NOT NULL,
+	created      TEXT NOT NULL,
+	changed      TEXT NOT NULL,
+	raw          TEXT NOT NULL
+);
+CREATE INDEX IF NOT EXISTS events_status ON events (status);
+
+CREATE TABLE IF NOT EXISTS ticket_classes (
+	id             TEXT PRIMARY KEY,
+	event_id       TEXT NOT NULL,
+	name           TEXT NOT NULL,
+	currency       TEXT NOT NULL,
+	cost           INTEGER NOT NULL,
+	free           INTEGER NOT NULL,
+	quantity_total INTEGER NOT NULL,
+	quantity_sold  INTEGER NOT NULL,
+	raw            TEXT NOT NULL
+);
+CREATE INDEX IF NOT EXISTS ticket_classes_event ON ticket_classes (event_id);
+
+CREATE TABLE IF NOT EXISTS orders (
+	id         TEXT PRIMARY KEY,
+	event_id   TEXT NOT NULL,
+	name       TEXT NOT NULL,
+	email      TEXT NOT NULL,
+	currency   TEXT NOT NULL,
+	gross      INTEGER NOT NULL,
+	created    TEXT NOT NULL,
+	changed    TEXT NOT NULL,
+	raw        TEXT NOT NULL
+);
+CREATE INDEX IF NOT EXISTS orders_event ON orders (event_id);
+
+CREATE TABLE IF NOT EXISTS attendees (
+	id                TEXT PRIMARY KEY,
+	event_id          TEXT NOT NULL,
+	order_id          TEXT NOT NULL,
+	ticket_class_id   TEXT NOT NULL,
+	ticket_class_name TEXT NOT NULL,
+	name              TEXT NOT NULL,
+	email             TEXT NOT NULL,
+	status            TEXT NOT NULL,
+	checked_in        INTEGER NOT NULL,
+	cancelled         INTEGER NOT NULL,
+	refunded          INTEGER NOT NULL,
+	created           TEXT NOT NULL,
+	changed           TEXT NOT NULL,
+	raw               TEXT NOT NULL
+);
+CREATE INDEX IF NOT EXISTS attendees_event ON attendees (event_id);
+CREATE INDEX IF NOT EXISTS attendees_order ON attendees (order_id);
+
+CREATE TABLE IF NOT EXISTS sync_state (
+	key           TEXT PRIMARY KEY,
+	changed_since TEXT NOT NULL,
+	refreshed     TEXT NOT NULL
+);
+`
@@ -0,0 +1,147 @@
+package mirror
+
+import (
+	"database/sql"
+	"encoding/json"
+	"strings"
+	"time"
+
+	"github.com/apzuk3/go-eventbrite"
+
+	"golang.org/x/net/context"
+)
+
+// Store is the read-only query API of a mirror. Its connections are opened read-only, so that
+// ad hoc queries can not alter the mirror.
+type Store struct {
+	db *sql.DB
+}
+
+// OpenStore opens the mirror database at path read-only, typically from a process that does not
+// refresh the mirror itself
+func OpenStore(path string) (*Store, error) {
+	db, err := sql.Open("sqlite", "file:"+path+"?mode=ro&_pragma=busy_timeout(5000)&_pragma=query_only(1)")
+	if err != nil {
+		return nil, err
+	}
+	return &Store{db: db}, nil
+}
+
+// Close closes the connections of the store
+func (s *Store) Close() error {
+	return s.db.Close()
+}
+
+// Query runs a SQL query against the mirror tables: organizers, venues, events, ticket_classes,
+// orders and attendees. The raw column of every table holds the object as returned by the API.
+//
+//	rows, err := store.Query(ctx, `
+//		SELECT e.name, count(*) FROM attendees a JOIN events e ON e.id = a.event_id
+//		WHERE e.status = 'live' AND a.ticket_class_name = ? GROUP BY e.id`, "VIP")
+func (s *Store) Query(ctx context.Context, query string, args ...interface{}) (*sql.Rows, error) {
+	return s.db.QueryContext(ctx, query, args...)
+}
+
+// QueryRow runs a SQL query expected to return at most one row
+func (s *Store) QueryRow(ctx context.Context, query string, args ...interface{}) *sql.Row {
+	return s.db.QueryRowContext(ctx, query, args...)
+}
+
+// LastRefresh returns when orders and attendees were last refreshed, the zero time before the
+// first refresh
+func (s *Store) LastRefresh(ctx context.Context) (time.Time, error) {
+	var refreshed sql.NullString
+	err := s.db.QueryRowContext(ctx, "SELECT min(refreshed) FROM sync_state").Scan(&refreshed)
+	if err != nil || !refreshed.Valid {
+		return time.Time{}, err
+	}
+	return time.Parse(changedSinceLayout, refreshed.String)
+}
+
+// Events returns the events with one of the given statuses, every event when none is given,
+// ordered by start time
+func (s *Store) Events(ctx context.Context, statuses ...string) ([]eventbrite.Event, error) {
+	query := "SELECT raw FROM events"
+	args := make([]interface{}, len(statuses))
+	if len(statuses) > 0 {
+		query += " WHERE status IN (?" + strings.Repeat(", ?", len(statuses)-1) + ")"
+		for i, status := range statuses {
+			args[i] = status
+		}
+	}
+	return decodeRows[eventbrite.Event](ctx, s.db, query+" ORDER BY start_utc", args...)
+}
+
+// TicketClasses returns the ticket classes of an event
+func (s *Store) TicketClasses(ctx context.Context, eventID string) ([]eventbrite.TicketClass, error) {
+	return decodeRows[eventbrite.TicketClass](ctx, s.db, "SELECT raw FROM ticket_classes WHERE event_id = ? ORDER BY id", eventID)
+}
+
+// Orders returns the orders of an event
+func (s *Store) Orders(ctx context.Context, eventID string) ([]eventbrite.Order, error) {
+	return decodeRows[eventbrite.Order](ctx, s.db, "SELECT raw FROM orders WHERE event_id = ? ORDER BY created", eventID)
+}
+
+// Attendees returns the attendees of an event
+func (s *Store) Attendees(ctx context.Context, eventID string) ([]eventbrite.Attendee, error) {
+	return decodeRows[eventbrite.Attendee](ctx, s.db, "SELECT raw FROM attendees WHERE event_id = ? ORDER BY created", eventID)
+}
+
+// AttendeeFilter selects attendees, empty fields match every attendee
+type AttendeeFilter struct {
+	// The status of the event of the attendee, like live or ended
+	EventStatus string
+	// The event of the attendee
+	EventID string
+	// The name of the ticket class of the attendee
+	TicketClassName string
+	// Include the cancelled and refunded attendees
+	IncludeCancelled bool
+}
+
+// CountAttendees returns the number of attendees matching the filter
+func (s *Store) CountAttendees(ctx context.Context, f AttendeeFilter) (int, error) {
+	query := "SELECT count(*) FROM attendees a JOIN events e ON e.id = a.event_id WHERE 1 = 1"
+	var args []interface{}
+	if f.EventStatus != "" {
+		query += " AND e.status = ?"
+		args = append(args, f.EventStatus)
+	}
+	if f.EventID != "" {
+		query += " AND a.event_id = ?"
+		args = append(args, f.EventID)
+	}
+	if f.TicketClassName != "" {
+		query += " AND a.ticket_class_name = ?"
+		args = append(args, f.TicketClassName)
+	}
+	if !f.IncludeCancelled {
+		query += " AND a.cancelled = 0 AND a.refunded = 0"
+	}
+
+	var n int
+	return n, s.db.QueryRowContext(ctx, query, args...).Scan(&n)
+}
+
+// decodeRows decodes the raw column returned by the query
+func decodeRows[T any](ctx context.Context, db *sql.DB, query string, args ...interface{}) ([]T, error) {
+	rows, err := db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	res := []T{}
+	for rows.Next() {
+		var raw string
+		if err := rows.Scan(&raw); err != nil {
+			return nil, err
+		}
+		var v T
+		if err := json.Unmarshal([]byte(raw), &v); err != nil {
+			return nil, err
+		}
+		res = append(res, v)
+	}
+	return res, rows.Err()
+}
@@ -8,6 +8,11 @@ type NotificationsResult struct {
 	Pagination    Pagination
 }
 
+// NotificationsRequest is the request structure for notifications
+type NotificationsRequest struct {
+	PageRequest
+}
+
 // Notification is the representation of something that Eventbrite has notified to its users.
 //
 // see @https://www.eventbrite.com/developer/v3/response_formats/notification/#ebapi-std:format-notification
@@ -34,8 +39,19 @@ type Notification struct {
 // Notifications gets a paginated response of notification objects for a determined user.
 //
 // https://www.eventbrite.com/developer/v3/endpoints/notifications/#ebapi-get-users-me-notifications
-func (c *Client) Notifications(ctx context.Context) (*NotificationsResult, error) {
+func (c *Client) Notifications(ctx context.Context, req *NotificationsRequest) (*NotificationsResult, error) {
 	res := new(NotificationsResult)
 
-	return res, c.getJSON(ctx, "/notificaitons", nil, res)
+	return res, c.getJSON(ctx, "Notifications", "/users/me/notifications/", req, res)
+}
+
+// NotificationsIterator returns an Iterator over every notification of the authenticated user
+func (c *Client) NotificationsIterator(ctx context.Context, req *NotificationsRequest) *Iterator[Notification] {
+	return iterate(ctx, req, func(ctx context.Context, r *NotificationsRequest) ([]Notification, Pagination, error) {
+		res, err := c.Notifications(ctx, r)
+		if err != nil {
+			return nil, Pagination{}, err
+		}
+		return res.Notifications, res.Pagination, nil
+	})
 }
@@ -0,0 +1,60 @@
+package eventbrite
+
+import (
+	"net/http"
+
+	"golang.org/x/oauth2"
+)
+
+// OAuthEndpoint is the OAuth 2.0 endpoint of Eventbrite
+//
+// https://www.eventbrite.com/platform/docs/authentication
+var OAuthEndpoint = oauth2.Endpoint{
+	AuthURL:   "https://www.eventbrite.com/oauth/authorize",
+	TokenURL:  "https://www.eventbrite.com/oauth/token",
+	AuthStyle: oauth2.AuthStyleInParams,
+}
+
+// NewOAuthConfig returns the OAuth 2.0 configuration of an Eventbrite app, given its API key
+// and client secret. The redirect url must match the one registered with the app.
+//
+// Users are sent to the url returned by AuthCodeURL and come back to the redirect url with a
+// code, which Exchange trades for their access token
+//
+//	conf := eventbrite.NewOAuthConfig(API_KEY, CLIENT_SECRET, "https://example.com/callback")
+//	http.Redirect(w, r, conf.AuthCodeURL(state), http.StatusFound)
+//
+//	// in the callback
+//	token, err := conf.Exchange(ctx, r.URL.Query().Get("code"))
+//	clnt, err := eventbrite.NewClient(eventbrite.WithTokenSource(conf.TokenSource(ctx, token)))
+func NewOAuthConfig(clientID, clientSecret, redirectURL string) *oauth2.Config {
+	return &oauth2.Config{
+		ClientID:     clientID,
+		ClientSecret: clientSecret,
+		RedirectURL:  redirectURL,
+		Endpoint:     OAuthEndpoint,
+	}
+}
+
+// WithTokenSource configures a Eventbrite API client to authenticate every request with a bearer
+// token taken from ts, sent in the Authorization header. It takes precedence over WithToken.
+func WithTokenSource(ts oauth2.TokenSource) ClientOption {
+	return func(c *Client) error {
+		c.tokenSource = ts
+		return nil
+	}
+}
+
+// authorize sets the Authorization header of the request when the client has a token source
+func (c *Client) authorize(req *http.Request) error {
+	if c.tokenSource == nil {
+		return nil
+	}
+
+	token, err := c.tokenSource.Token()
+	if err != nil {
+		return err
+	}
+	token.SetAuthHeader(req)
+	return nil
+}
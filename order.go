@@ -10,6 +10,8 @@ import (
 //
 // https://www.eventbrite.com/developer/v3/response_formats/order/#ebapi-std:format-order
 type Order struct {
+	// The order ID
+	ID string `json:"id"`
 	// When the attendee was created (order placed)
 	Created DateTime `json:"created"`
 	// When the attendee was last changed
@@ -57,5 +59,5 @@ type OrderCosts struct {
 func (c *Client) OrderGet(ctx context.Context, id string) (*Order, error) {
 	o := new(Order)
 
-	return o, c.getJSON(ctx, fmt.Sprintf("/orders/%s/", id), nil, o)
+	return o, c.getJSON(ctx, "OrderGet", fmt.Sprintf("/orders/%s/", id), nil, o)
 }
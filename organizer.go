@@ -54,6 +54,8 @@ type UpdateOrganizerRequest struct {
 //
 // https://www.eventbrite.co.uk/developer/v3/endpoints/organizers/#ebapi-id6
 type OrganizerEventsRequest struct {
+	PageRequest
+
 	// Only return events with a specific status set. This should be a comma delimited string of status.
 	// Valid status: all, draft, live, canceled, started, ended.
 	Status string `json:"status"`
@@ -81,16 +83,16 @@ type OrganizerEventsResult struct {
 func (c *Client) OrganizerCreate(ctx context.Context, req *CreateOrganizerRequest) (*Organizer, error) {
 	resp := new(Organizer)
 
-	return resp, c.postJSON(ctx, "/organizers/", req, resp)
+	return resp, c.postJSON(ctx, "OrganizerCreate", "/organizers/", req, resp)
 }
 
-// OrganizerCreate gets an organizer by ID as organizer.
+// OrganizerGet gets an organizer by ID as organizer.
 //
 // https://www.eventbrite.com/developer/v3/endpoints/organizers/#ebapi-get-organizers-id
 func (c *Client) OrganizerGet(ctx context.Context, id string) (*Organizer, error) {
 	resp := new(Organizer)
 
-	return resp, c.postJSON(ctx, "/organizers/"+id, nil, resp)
+	return resp, c.getJSON(ctx, "OrganizerGet", "/organizers/"+id, nil, resp)
 }
 
 // OrganizerCreate updates an organizer and returns it as as organizer.
@@ -99,7 +101,7 @@ func (c *Client) OrganizerGet(ctx context.Context, id string) (*Organizer, error
 func (c *Client) OrganizerUpdate(ctx context.Context, id string, req *UpdateOrganizerRequest) (*Organizer, error) {
 	resp := new(Organizer)
 
-	return resp, c.postJSON(ctx, "/organizers/"+id, req, resp)
+	return resp, c.postJSON(ctx, "OrganizerUpdate", "/organizers/"+id, req, resp)
 }
 
 // OrganizerCreate gets events of the organizer.
@@ -108,5 +110,16 @@ func (c *Client) OrganizerUpdate(ctx context.Context, id string, req *UpdateOrga
 func (c *Client) OrganizerGetEvents(ctx context.Context, id string, req *OrganizerEventsRequest) (*OrganizerEventsResult, error) {
 	resp := new(OrganizerEventsResult)
 
-	return resp, c.getJSON(ctx, fmt.Sprintf("/organizers/%s/events/", id), req, resp)
+	return resp, c.getJSON(ctx, "OrganizerGetEvents", fmt.Sprintf("/organizers/%s/events/", id), req, resp)
+}
+
+// OrganizerEventsIterator returns an Iterator over every event of the organizer
+func (c *Client) OrganizerEventsIterator(ctx context.Context, id string, req *OrganizerEventsRequest) *Iterator[Event] {
+	return iterate(ctx, req, func(ctx context.Context, r *OrganizerEventsRequest) ([]Event, Pagination, error) {
+		res, err := c.OrganizerGetEvents(ctx, id, r)
+		if err != nil {
+			return nil, Pagination{}, err
+		}
+		return res.Events, res.Pagination, nil
+	})
 }
@@ -0,0 +1,147 @@
+package eventbrite
+
+import "golang.org/x/net/context"
+
+// PageRequest holds the pagination parameters accepted by every list endpoint. It is embedded
+// into the list request structures, so a specific page can be requested by setting Page or by
+// passing back the Continuation token of the previous response.
+//
+// https://www.eventbrite.com/developer/v3/api_overview/pagination/#ebapi-paginated-responses
+type PageRequest struct {
+	// The page number to fetch, starting from 1
+	Page int `json:"page" url:",omitempty"`
+	// The continuation token returned in the pagination of the previous page
+	Continuation string `json:"continuation"`
+}
+
+// pageRequest returns the PageRequest embedded into a list request structure
+func (p *PageRequest) pageRequest() *PageRequest {
+	return p
+}
+
+// listRequest is a pointer to a list request structure, which embeds a PageRequest
+type listRequest[R any] interface {
+	*R
+	pageRequest() *PageRequest
+}
+
+// iterate returns an Iterator over a list endpoint of the client. list is called with a copy of
+// req, the zero request when req is nil, whose PageRequest is set to each page in turn.
+func iterate[T, R any, P listRequest[R]](ctx context.Context, req *R, list func(ctx context.Context, req *R) ([]T, Pagination, error)) *Iterator[T] {
+	var r R
+	if req != nil {
+		r = *req
+	}
+
+	return NewIterator(ctx, *P(&r).pageRequest(), func(ctx context.Context, page PageRequest) ([]T, Pagination, error) {
+		*P(&r).pageRequest() = page
+		return list(ctx, &r)
+	})
+}
+
+// PageFunc fetches a single page of a list endpoint and returns its items along with the
+// pagination of the response
+type PageFunc[T any] func(ctx context.Context, page PageRequest) ([]T, Pagination, error)
+
+// Iterator walks every item of a paginated list endpoint, following HasMoreItems and the
+// continuation token across calls. Pages are fetched lazily, so each one goes through the
+// client rate limiter and the context of the iterator is checked before every call.
+//
+//	it := clnt.UserOwnedEventsIterator(ctx, "me", nil)
+//	for it.Next() {
+//		fmt.Println(it.Item().Name.Text)
+//	}
+//	if err := it.Err(); err != nil {
+//		// handle me
+//	}
+type Iterator[T any] struct {
+	ctx        context.Context
+	fetch      PageFunc[T]
+	page       PageRequest
+	pagination Pagination
+	items      []T
+	item       T
+	err        error
+	done       bool
+}
+
+// NewIterator returns an Iterator that fetches pages with fetch starting from the given page
+func NewIterator[T any](ctx context.Context, start PageRequest, fetch PageFunc[T]) *Iterator[T] {
+	return &Iterator[T]{
+		ctx:   ctx,
+		fetch: fetch,
+		page:  start,
+	}
+}
+
+// Next advances the iterator to the next item, fetching the next page when the current one is
+// exhausted. It returns false when there are no more items or an error occurred.
+func (it *Iterator[T]) Next() bool {
+	for len(it.items) == 0 {
+		if it.done || it.err != nil {
+			return false
+		}
+		if err := it.ctx.Err(); err != nil {
+			it.err = err
+			return false
+		}
+
+		items, pagination, err := it.fetch(it.ctx, it.page)
+		if err != nil {
+			it.err = err
+			return false
+		}
+
+		it.items = items
+		it.pagination = pagination
+		it.done = !pagination.HasMoreItems
+		it.page = nextPage(it.page, pagination)
+	}
+
+	it.item = it.items[0]
+	it.items = it.items[1:]
+	return true
+}
+
+// Item returns the current item. It is only valid after a call to Next returned true.
+func (it *Iterator[T]) Item() T {
+	return it.item
+}
+
+// Err returns the error, if any, that stopped the iteration
+func (it *Iterator[T]) Err() error {
+	return it.err
+}
+
+// Pagination returns the pagination of the last fetched page
+func (it *Iterator[T]) Pagination() Pagination {
+	return it.pagination
+}
+
+// Walk calls fn for every remaining item of the iterator. It stops at the first error returned
+// by fn or by the underlying requests.
+func (it *Iterator[T]) Walk(fn func(T) error) error {
+	for it.Next() {
+		if err := fn(it.Item()); err != nil {
+			return err
+		}
+	}
+	return it.Err()
+}
+
+// nextPage builds the parameters for the page following the given response pagination.
+// The continuation token is preferred since the API requires it on endpoints supporting it.
+func nextPage(current PageRequest, p Pagination) PageRequest {
+	if p.Continuation != "" {
+		return PageRequest{Continuation: p.Continuation}
+	}
+
+	page := p.PageNumber
+	if page == 0 {
+		page = current.Page
+		if page == 0 {
+			page = 1
+		}
+	}
+	return PageRequest{Page: page + 1}
+}
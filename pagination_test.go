@@ -0,0 +1,85 @@
+package eventbrite
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"golang.org/x/net/context"
+)
+
+func TestIterator(t *testing.T) {
+	var queries []string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		q := r.URL.Query()
+		queries = append(queries, "status="+q.Get("status")+"&page="+q.Get("page")+"&continuation="+q.Get("continuation"))
+		switch q.Get("continuation") {
+		case "":
+			fmt.Fprint(w, `{"pagination":{"page_number":1,"has_more_items":true,"continuation":"c2"},"attendees":[{"id":"1"},{"id":"2"}]}`)
+		case "c2":
+			fmt.Fprint(w, `{"pagination":{"page_number":2,"has_more_items":false},"attendees":[{"id":"3"}]}`)
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer srv.Close()
+
+	clnt, err := NewClient(WithToken("token"), WithBaseURL(srv.URL), WithRateLimit(0))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name        string
+		req         *EventGetAttendees
+		wantQueries string
+	}{
+		{"nil request", nil, "status=&page=&continuation= status=&page=&continuation=c2"},
+		{"filtered", &EventGetAttendees{Status: "attending"}, "status=attending&page=&continuation= status=attending&page=&continuation=c2"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			queries = nil
+			var ids []string
+			err := clnt.EventAttendeesIterator(context.Background(), "1", tt.req).Walk(func(a Attendee) error {
+				ids = append(ids, a.ID)
+				return nil
+			})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got := strings.Join(ids, ","); got != "1,2,3" {
+				t.Errorf("attendees = %s, want 1,2,3", got)
+			}
+			if got := strings.Join(queries, " "); got != tt.wantQueries {
+				t.Errorf("queries = %s\nwant      %s", got, tt.wantQueries)
+			}
+			if tt.req != nil && tt.req.Continuation != "" {
+				t.Errorf("the request of the caller was modified: %+v", tt.req.PageRequest)
+			}
+		})
+	}
+}
+
+func TestNilListRequest(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"pagination":{"page_number":1},"attendees":[{"id":"1"}]}`)
+	}))
+	defer srv.Close()
+
+	clnt, err := NewClient(WithToken("token"), WithBaseURL(srv.URL), WithRateLimit(0))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	res, err := clnt.EventAttendees(context.Background(), "1", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(res.Attendees) != 1 {
+		t.Errorf("attendees = %+v, want one", res.Attendees)
+	}
+}
@@ -72,5 +72,5 @@ type FeeResponse struct {
 func (c *Client) FeeRate(ctx context.Context, req *FeeRequest) (*FeeResponse, error) {
 	res := new(FeeResponse)
 
-	return res, c.getJSON(ctx, "/pricing/fee_rates", req, res)
+	return res, c.getJSON(ctx, "FeeRate", "/pricing/fee_rates", req, res)
 }
@@ -0,0 +1,265 @@
+package eventbrite
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// encodeQuery encodes a request structure as the query of a GET request.
+//
+// The name of a field is taken from its url tag, or from its json tag when it has none. Options
+// follow the name in the url tag, separated by commas:
+//
+//	omitempty  the field is skipped when it has its zero value
+//	repeat     slices are sent as one parameter per item instead of a comma separated list
+//	json       the value is sent JSON encoded, like the filter_by dictionary of reports
+//
+// A url tag without name, like `url:",omitempty"`, keeps the name of the json tag. Fields named
+// through their json tag are omitted when they are empty strings, slices, maps, structs or times,
+// which have nothing to send, while their booleans and numbers are always sent, false and 0
+// included. Fields whose zero value means unset, like a page number, opt in with omitempty. A
+// nil pointer is always omitted, and a pointer to a zero value is sent even with omitempty.
+// Nested structs and maps are flattened to dotted keys, embedded structs are flattened without
+// prefix, and times are sent in UTC.
+func encodeQuery(i interface{}) (url.Values, error) {
+	if q, ok := i.(url.Values); ok {
+		return q, nil
+	}
+
+	values := url.Values{}
+	if i == nil {
+		return values, nil
+	}
+
+	v := reflect.ValueOf(i)
+	for v.Kind() == reflect.Ptr || v.Kind() == reflect.Interface {
+		if v.IsNil() {
+			return values, nil
+		}
+		v = v.Elem()
+	}
+	if v.Kind() != reflect.Struct {
+		return nil, fmt.Errorf("eventbrite: cannot encode %s as a query", v.Type())
+	}
+
+	return values, encodeStruct(values, "", v)
+}
+
+// queryTag is the parsed query tag of a field
+type queryTag struct {
+	name      string
+	omitEmpty bool
+	repeat    bool
+	json      bool
+	// fromJSON is set when the field has no url tag, so that its empty values without a
+	// meaning of their own are omitted
+	fromJSON bool
+}
+
+func parseQueryTag(f reflect.StructField) (queryTag, bool) {
+	jsonName := func() string {
+		tag, _ := f.Tag.Lookup("json")
+		return strings.Split(tag, ",")[0]
+	}
+
+	tag, ok := f.Tag.Lookup("url")
+	if !ok {
+		// the json options do not apply to queries
+		_, ok = f.Tag.Lookup("json")
+		return queryTag{name: jsonName(), fromJSON: true}, ok
+	}
+
+	parts := strings.Split(tag, ",")
+	t := queryTag{name: parts[0]}
+	if t.name == "" {
+		t.name = jsonName()
+	}
+	for _, opt := range parts[1:] {
+		switch opt {
+		case "omitempty":
+			t.omitEmpty = true
+		case "repeat":
+			t.repeat = true
+		case "json":
+			t.json = true
+		}
+	}
+	return t, true
+}
+
+func encodeStruct(values url.Values, prefix string, v reflect.Value) error {
+	typ := v.Type()
+	for i := 0; i < typ.NumField(); i++ {
+		f := typ.Field(i)
+		if f.PkgPath != "" && !f.Anonymous {
+			continue
+		}
+
+		tag, tagged := parseQueryTag(f)
+		if tag.name == "-" {
+			continue
+		}
+
+		fv := v.Field(i)
+		if f.Anonymous && !tagged {
+			for fv.Kind() == reflect.Ptr {
+				if fv.IsNil() {
+					break
+				}
+				fv = fv.Elem()
+			}
+			if fv.Kind() == reflect.Struct {
+				if err := encodeStruct(values, prefix, fv); err != nil {
+					return err
+				}
+				continue
+			}
+		}
+
+		if tag.name == "" {
+			tag.name = f.Name
+		}
+		if err := encodeValue(values, prefix+tag.name, fv, tag); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+var (
+	timeType     = reflect.TypeOf(time.Time{})
+	dateTimeType = reflect.TypeOf(DateTime{})
+	dateType     = reflect.TypeOf(Date{})
+)
+
+func encodeValue(values url.Values, key string, v reflect.Value, tag queryTag) error {
+	set := false
+	for v.Kind() == reflect.Ptr || v.Kind() == reflect.Interface {
+		if v.IsNil() {
+			return nil
+		}
+		set = set || v.Kind() == reflect.Ptr
+		v = v.Elem()
+	}
+	if !set && v.IsZero() && (tag.omitEmpty || tag.fromJSON && !isBoolOrNumber(v.Kind())) {
+		return nil
+	}
+
+	if tag.json {
+		b, err := json.Marshal(v.Interface())
+		if err != nil {
+			return err
+		}
+		values.Set(key, string(b))
+		return nil
+	}
+
+	switch v.Type() {
+	case timeType:
+		values.Set(key, formatTime(v.Interface().(time.Time)))
+		return nil
+	case dateTimeType:
+		values.Set(key, formatTime(v.Interface().(DateTime).Time))
+		return nil
+	case dateType:
+		values.Set(key, v.Interface().(Date).Time.Format("2006-01-02"))
+		return nil
+	}
+
+	switch v.Kind() {
+	case reflect.Struct:
+		return encodeStruct(values, key+".", v)
+	case reflect.Map:
+		if v.Type().Key().Kind() != reflect.String {
+			return fmt.Errorf("eventbrite: cannot encode %s as a query", v.Type())
+		}
+		iter := v.MapRange()
+		for iter.Next() {
+			if err := encodeValue(values, key+"."+iter.Key().String(), iter.Value(), queryTag{omitEmpty: tag.omitEmpty, fromJSON: tag.fromJSON}); err != nil {
+				return err
+			}
+		}
+		return nil
+	case reflect.Slice, reflect.Array:
+		if v.Type().Elem().Kind() == reflect.Uint8 {
+			values.Set(key, string(v.Bytes()))
+			return nil
+		}
+
+		items := make([]string, 0, v.Len())
+		for i := 0; i < v.Len(); i++ {
+			s, err := scalar(v.Index(i))
+			if err != nil {
+				return err
+			}
+			items = append(items, s)
+		}
+		if tag.repeat {
+			values[key] = append(values[key], items...)
+		} else {
+			values.Set(key, strings.Join(items, ","))
+		}
+		return nil
+	}
+
+	s, err := scalar(v)
+	if err != nil {
+		return err
+	}
+	values.Set(key, s)
+	return nil
+}
+
+// isBoolOrNumber reports whether the zero value of the kind is a value to send
+func isBoolOrNumber(k reflect.Kind) bool {
+	switch k {
+	case reflect.Bool,
+		reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+		reflect.Float32, reflect.Float64:
+		return true
+	}
+	return false
+}
+
+// scalar formats a single value
+func scalar(v reflect.Value) (string, error) {
+	for v.Kind() == reflect.Ptr || v.Kind() == reflect.Interface {
+		if v.IsNil() {
+			return "", nil
+		}
+		v = v.Elem()
+	}
+
+	switch v.Type() {
+	case timeType:
+		return formatTime(v.Interface().(time.Time)), nil
+	case dateTimeType:
+		return formatTime(v.Interface().(DateTime).Time), nil
+	}
+
+	switch v.Kind() {
+	case reflect.String:
+		return v.String(), nil
+	case reflect.Bool:
+		return strconv.FormatBool(v.Bool()), nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return strconv.FormatInt(v.Int(), 10), nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return strconv.FormatUint(v.Uint(), 10), nil
+	case reflect.Float32:
+		return strconv.FormatFloat(v.Float(), 'f', -1, 32), nil
+	case reflect.Float64:
+		return strconv.FormatFloat(v.Float(), 'f', -1, 64), nil
+	}
+	return "", fmt.Errorf("eventbrite: cannot encode %s as a query value", v.Type())
+}
+
+func formatTime(t time.Time) string {
+	return t.UTC().Format("2006-01-02T15:04:05Z")
+}
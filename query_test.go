@@ -0,0 +1,158 @@
+package eventbrite
+
+import (
+	"net/url"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestEncodeQuery(t *testing.T) {
+	type flags struct {
+		Plain    bool   `json:"plain"`
+		Number   int    `json:"number"`
+		Name     string `json:"name"`
+		Pointer  *bool  `json:"pointer"`
+		Explicit bool   `url:"explicit"`
+		Omitted  bool   `json:"omitted" url:",omitempty"`
+		Count    *int   `url:"count,omitempty"`
+	}
+	zero := 0
+
+	tests := []struct {
+		name string
+		req  interface{}
+		want url.Values
+	}{
+		{"nil", nil, url.Values{}},
+		{"nil pointer", (*flags)(nil), url.Values{}},
+		{"unset", flags{}, url.Values{"plain": {"false"}, "number": {"0"}, "explicit": {"false"}}},
+		{
+			name: "set",
+			req:  flags{Plain: true, Number: 3, Name: "n", Pointer: Bool(true), Explicit: true, Omitted: true},
+			want: url.Values{
+				"plain": {"true"}, "number": {"3"}, "name": {"n"}, "pointer": {"true"}, "explicit": {"true"}, "omitted": {"true"},
+			},
+		},
+		{
+			name: "pointers to zero values",
+			req:  &flags{Pointer: Bool(false), Count: &zero},
+			want: url.Values{"plain": {"false"}, "number": {"0"}, "pointer": {"false"}, "explicit": {"false"}, "count": {"0"}},
+		},
+		{
+			name: "search",
+			req:  &EventSearchRequest{Query: "jazz", IncludeAllSeriesInstances: true},
+			want: url.Values{
+				"q":                            {"jazz"},
+				"include_all_series_instances": {"true"},
+				"include_unavailable_events":   {"false"},
+				"include_adult_events":         {"false"},
+				"incorporate_user_affinities":  {"false"},
+			},
+		},
+		{
+			name: "page and list filters",
+			req:  &EventGetAttendees{Status: "attending"},
+			want: url.Values{"status": {"attending"}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := encodeQuery(tt.req)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("encodeQuery() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestEncodeQueryValues(t *testing.T) {
+	type address struct {
+		City    string `json:"city"`
+		Country string `json:"country"`
+	}
+	type request struct {
+		PageRequest
+
+		IDs      []string          `json:"ids"`
+		Repeated []int             `url:"item,repeat,omitempty"`
+		Filter   map[string]string `url:"filter_by,json,omitempty"`
+		Address  address           `json:"address"`
+		Extra    map[string]string `json:"extra"`
+		Since    time.Time         `json:"since"`
+		Changed  DateTime          `json:"changed"`
+		Day      Date              `url:"day,omitempty"`
+		Ratio    float64           `json:"ratio" url:",omitempty"`
+		Skipped  string            `url:"-"`
+		hidden   string
+	}
+	paris := time.FixedZone("CEST", 2*3600)
+
+	tests := []struct {
+		name string
+		req  interface{}
+		want url.Values
+	}{
+		{"raw values", url.Values{"q": {"jazz"}}, url.Values{"q": {"jazz"}}},
+		{"empty", request{}, url.Values{}},
+		{"page", request{PageRequest: PageRequest{Page: 2, Continuation: "c"}}, url.Values{"page": {"2"}, "continuation": {"c"}}},
+		{"joined slice", request{IDs: []string{"1", "2"}}, url.Values{"ids": {"1,2"}}},
+		{"repeated slice", request{Repeated: []int{1, 2}}, url.Values{"item": {"1", "2"}}},
+		{"json", request{Filter: map[string]string{"currency": "USD"}}, url.Values{"filter_by": {`{"currency":"USD"}`}}},
+		{"nested struct", request{Address: address{City: "Paris"}}, url.Values{"address.city": {"Paris"}}},
+		{"map", request{Extra: map[string]string{"a": "1", "b": ""}}, url.Values{"extra.a": {"1"}}},
+		{
+			name: "times in utc",
+			req: request{
+				Since:   time.Date(2026, 7, 1, 20, 0, 0, 0, paris),
+				Changed: DateTime{Time: time.Date(2026, 7, 1, 20, 0, 0, 0, paris)},
+				Day:     Date{Time: time.Date(2026, 7, 1, 0, 0, 0, 0, time.UTC)},
+			},
+			want: url.Values{"since": {"2026-07-01T18:00:00Z"}, "changed": {"2026-07-01T18:00:00Z"}, "day": {"2026-07-01"}},
+		},
+		{"float", request{Ratio: 0.25}, url.Values{"ratio": {"0.25"}}},
+		{"skipped", request{Skipped: "x", hidden: "y"}, url.Values{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := encodeQuery(tt.req)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("encodeQuery() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestEncodeQueryErrors(t *testing.T) {
+	tests := []struct {
+		name string
+		req  interface{}
+		want string
+	}{
+		{"not a struct", 42, "cannot encode int"},
+		{"map key", struct {
+			M map[int]string `json:"m"`
+		}{M: map[int]string{1: "a"}}, "cannot encode map[int]string"},
+		{"channel", struct {
+			C chan int `json:"c"`
+		}{C: make(chan int)}, "cannot encode chan int"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := encodeQuery(tt.req)
+			if err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("encodeQuery() error = %v, want %q", err, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,310 @@
+package eventbrite
+
+import (
+	"sync"
+	"time"
+
+	"golang.org/x/net/context"
+)
+
+// Quota is the call budget Eventbrite grants a token
+//
+// https://www.eventbrite.com/platform/docs/rate-limits
+type Quota struct {
+	// The number of calls allowed in any hour, unlimited when zero
+	Hourly int
+	// The number of calls allowed in any day, unlimited when zero
+	Daily int
+}
+
+// DefaultQuota is the default budget of an Eventbrite token
+var DefaultQuota = Quota{Hourly: 2000, Daily: 48000}
+
+// RateLimitObserver is implemented by the limiters reacting to the HIT_RATE_LIMIT errors of
+// Eventbrite. The client reports every response with HTTP status 429 to its limiter, along with
+// the delay asked by its Retry-After header, zero when there is none.
+type RateLimitObserver interface {
+	ObserveRateLimit(retryAfter time.Duration)
+}
+
+// QuotaStatus is the budget left to an AdaptiveLimiter
+type QuotaStatus struct {
+	// The calls made in the last hour and the last day
+	HourlyUsed, DailyUsed int
+	// The calls left in the hourly and daily budgets, -1 when unlimited
+	HourlyRemaining, DailyRemaining int
+	// The spacing currently enforced between two calls
+	Interval time.Duration
+	// Calls are suspended until then after a HIT_RATE_LIMIT error, zero when they are not
+	BlockedUntil time.Time
+}
+
+// AdaptiveLimiter is a RateLimiter keeping a token within its hourly and daily call budgets.
+// Calls go through freely until SlowdownAt of a budget is used, then they are spaced more and
+// more as the budget drains, and held when it is exhausted until the oldest calls leave the
+// sliding window. A HIT_RATE_LIMIT error suspends every call for the Retry-After delay, or an
+// exponential backoff when Eventbrite does not tell.
+//
+//	limiter := eventbrite.NewAdaptiveLimiter(eventbrite.DefaultQuota)
+//	clnt, err := eventbrite.NewClient(eventbrite.WithToken(token), eventbrite.WithRateLimiter(limiter))
+//
+//	if s := limiter.Status(); s.HourlyRemaining > 500 {
+//		// run the backfill
+//	}
+//
+// The exported fields must be set before the first call. The zero value has no budget and lets
+// every call through until a HIT_RATE_LIMIT error.
+type AdaptiveLimiter struct {
+	quota Quota
+
+	// The fraction of a budget used after which calls are spaced, 0.5 by default
+	SlowdownAt float64
+	// The suspension after a first HIT_RATE_LIMIT error without Retry-After, doubled on every
+	// following one up to MaxBackoff
+	MinBackoff time.Duration
+	MaxBackoff time.Duration
+
+	mu           sync.Mutex
+	minutes      [minutesPerDay]int64 // minute of each slot, in minutes since the Unix epoch
+	counts       [minutesPerDay]int   // calls made during that minute
+	last         time.Time
+	blockedUntil time.Time
+	hits         int
+	lastHit      time.Time
+	closed       chan struct{}
+	once         sync.Once
+	// now returns the current time, time.Now when nil
+	now func() time.Time
+}
+
+const minutesPerDay = 24 * 60
+
+// NewAdaptiveLimiter returns an AdaptiveLimiter for the given budget
+func NewAdaptiveLimiter(q Quota) *AdaptiveLimiter {
+	return &AdaptiveLimiter{
+		quota:      q,
+		SlowdownAt: 0.5,
+		MinBackoff: time.Minute,
+		MaxBackoff: time.Hour,
+	}
+}
+
+// WithAdaptiveRateLimit configures the client to keep within the given call budget with an
+// AdaptiveLimiter of its own, closed along with the client. Use WithRateLimiter to share one
+// between clients using the same token.
+func WithAdaptiveRateLimit(q Quota) ClientOption {
+	return func(c *Client) error {
+		c.limiter = NewAdaptiveLimiter(q)
+		c.ownsLimiter = true
+		c.requestsPerSecond = 0
+		return nil
+	}
+}
+
+// Wait implements RateLimiter
+func (l *AdaptiveLimiter) Wait(ctx context.Context) error {
+	closed := l.done()
+	for {
+		select {
+		case <-closed:
+			return ErrLimiterClosed
+		default:
+		}
+
+		l.mu.Lock()
+		now := l.clock()
+		delay := l.delay(now)
+		if delay <= 0 {
+			l.record(now)
+			l.mu.Unlock()
+			return nil
+		}
+		l.mu.Unlock()
+
+		t := time.NewTimer(delay)
+		select {
+		case <-t.C:
+		case <-ctx.Done():
+			t.Stop()
+			return ctx.Err()
+		case <-closed:
+			t.Stop()
+			return ErrLimiterClosed
+		}
+	}
+}
+
+// ObserveRateLimit implements RateLimitObserver
+func (l *AdaptiveLimiter) ObserveRateLimit(retryAfter time.Duration) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	now := l.clock()
+	// hits further apart than the longest backoff are not consecutive
+	if now.Sub(l.lastHit) > l.MaxBackoff {
+		l.hits = 0
+	}
+	l.hits++
+	l.lastHit = now
+
+	d := retryAfter
+	if d <= 0 {
+		d = l.MinBackoff << uint(l.hits-1)
+		if d > l.MaxBackoff || d <= 0 {
+			d = l.MaxBackoff
+		}
+	}
+	if until := now.Add(d); until.After(l.blockedUntil) {
+		l.blockedUntil = until
+	}
+}
+
+// Status returns the budget left
+func (l *AdaptiveLimiter) Status() QuotaStatus {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	now := l.clock()
+	s := QuotaStatus{
+		HourlyUsed:      l.used(now, 60),
+		DailyUsed:       l.used(now, minutesPerDay),
+		HourlyRemaining: -1,
+		DailyRemaining:  -1,
+		Interval:        l.interval(now),
+	}
+	if l.quota.Hourly > 0 {
+		s.HourlyRemaining = remaining(l.quota.Hourly, s.HourlyUsed)
+	}
+	if l.quota.Daily > 0 {
+		s.DailyRemaining = remaining(l.quota.Daily, s.DailyUsed)
+	}
+	if l.blockedUntil.After(now) {
+		s.BlockedUntil = l.blockedUntil
+	}
+	return s
+}
+
+func remaining(budget, used int) int {
+	if used >= budget {
+		return 0
+	}
+	return budget - used
+}
+
+// Close releases the callers waiting for the budget, they and every later caller of Wait get
+// ErrLimiterClosed
+func (l *AdaptiveLimiter) Close() error {
+	l.once.Do(func() { close(l.done()) })
+	return nil
+}
+
+// done returns the channel closed by Close, created on first use so that the zero value works
+func (l *AdaptiveLimiter) done() chan struct{} {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if l.closed == nil {
+		l.closed = make(chan struct{})
+	}
+	return l.closed
+}
+
+func (l *AdaptiveLimiter) clock() time.Time {
+	if l.now != nil {
+		return l.now()
+	}
+	return time.Now()
+}
+
+// delay returns how long to wait before the next call, l.mu must be held
+func (l *AdaptiveLimiter) delay(now time.Time) time.Duration {
+	if l.blockedUntil.After(now) {
+		return l.blockedUntil.Sub(now)
+	}
+
+	var delay time.Duration
+	for _, w := range l.windows() {
+		if w.budget <= 0 || l.used(now, w.minutes) < w.budget {
+			continue
+		}
+		if d := l.freed(now, w.minutes).Sub(now); d > delay {
+			delay = d
+		}
+	}
+	if delay > 0 {
+		return delay
+	}
+
+	return l.last.Add(l.interval(now)).Sub(now)
+}
+
+// interval returns the spacing enforced between two calls given the budgets used, l.mu must
+// be held. It grows from zero at SlowdownAt to the sustainable spacing of the budget halfway
+// to its end, and without bound past it.
+func (l *AdaptiveLimiter) interval(now time.Time) time.Duration {
+	var res time.Duration
+	for _, w := range l.windows() {
+		if w.budget <= 0 {
+			continue
+		}
+
+		f := float64(l.used(now, w.minutes)) / float64(w.budget)
+		if f < l.SlowdownAt {
+			continue
+		}
+		if f >= 1 {
+			f = 0.999
+		}
+		sustainable := time.Duration(w.minutes) * time.Minute / time.Duration(w.budget)
+		d := time.Duration(float64(sustainable) * (f - l.SlowdownAt) / (1 - f))
+		if d > res {
+			res = d
+		}
+	}
+	return res
+}
+
+type quotaWindow struct {
+	minutes int
+	budget  int
+}
+
+func (l *AdaptiveLimiter) windows() [2]quotaWindow {
+	return [2]quotaWindow{{60, l.quota.Hourly}, {minutesPerDay, l.quota.Daily}}
+}
+
+// record counts a call made now, l.mu must be held
+func (l *AdaptiveLimiter) record(now time.Time) {
+	m := now.Unix() / 60
+	i := m % minutesPerDay
+	if l.minutes[i] != m {
+		l.minutes[i], l.counts[i] = m, 0
+	}
+	l.counts[i]++
+	l.last = now
+}
+
+// used returns the calls made in the last given minutes, l.mu must be held
+func (l *AdaptiveLimiter) used(now time.Time, minutes int) int {
+	m := now.Unix() / 60
+	n := 0
+	for i, slot := range l.minutes {
+		if slot > m-int64(minutes) && slot <= m {
+			n += l.counts[i]
+		}
+	}
+	return n
+}
+
+// freed returns when the oldest calls of the last given minutes leave the window, l.mu must
+// be held
+func (l *AdaptiveLimiter) freed(now time.Time, minutes int) time.Time {
+	m := now.Unix() / 60
+	oldest := m
+	for i, slot := range l.minutes {
+		if slot > m-int64(minutes) && slot < oldest && l.counts[i] > 0 {
+			oldest = slot
+		}
+	}
+	return time.Unix((oldest+int64(minutes))*60, 0)
+}
@@ -0,0 +1,206 @@
+package eventbrite
+
+import (
+	"testing"
+	"time"
+
+	"golang.org/x/net/context"
+)
+
+// fakeClock is the clock of an AdaptiveLimiter under test, only moved by Add
+type fakeClock struct {
+	t time.Time
+}
+
+func (c *fakeClock) Now() time.Time { return c.t }
+
+func (c *fakeClock) Add(d time.Duration) { c.t = c.t.Add(d) }
+
+func newTestLimiter(q Quota) (*AdaptiveLimiter, *fakeClock) {
+	clock := &fakeClock{t: time.Date(2026, 7, 1, 12, 0, 30, 0, time.UTC)}
+	l := NewAdaptiveLimiter(q)
+	l.now = clock.Now
+	return l, clock
+}
+
+func TestAdaptiveLimiterBudget(t *testing.T) {
+	tests := []struct {
+		name   string
+		quota  Quota
+		budget int
+		window time.Duration
+		// the remaining count of the budget under test, and of the other one
+		remaining func(QuotaStatus) (int, int)
+	}{
+		{
+			name:   "hourly",
+			quota:  Quota{Hourly: 100},
+			budget: 100,
+			window: time.Hour,
+			remaining: func(s QuotaStatus) (int, int) {
+				return s.HourlyRemaining, s.DailyRemaining
+			},
+		},
+		{
+			name:   "daily",
+			quota:  Quota{Daily: 1000},
+			budget: 1000,
+			window: 24 * time.Hour,
+			remaining: func(s QuotaStatus) (int, int) {
+				return s.DailyRemaining, s.HourlyRemaining
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			l, clock := newTestLimiter(tt.quota)
+			defer l.Close()
+
+			cancelled, cancel := context.WithCancel(context.Background())
+			cancel()
+
+			// use records n calls made now
+			use := func(n int) {
+				l.mu.Lock()
+				defer l.mu.Unlock()
+				for i := 0; i < n; i++ {
+					l.record(clock.Now())
+				}
+			}
+			check := func(used int) QuotaStatus {
+				t.Helper()
+
+				s := l.Status()
+				if got, other := tt.remaining(s); got != tt.budget-used || other != -1 {
+					t.Errorf("remaining %d and %d after %d calls, want %d and unlimited", got, other, used, tt.budget-used)
+				}
+				return s
+			}
+
+			// below SlowdownAt, calls go through freely
+			use(tt.budget/2 - 1)
+			if s := check(tt.budget/2 - 1); s.Interval != 0 {
+				t.Errorf("interval = %v below SlowdownAt, want none", s.Interval)
+			}
+			if err := l.Wait(context.Background()); err != nil {
+				t.Fatal(err)
+			}
+
+			// past it, calls are spaced more and more as the budget drains
+			use(tt.budget / 10)
+			low := check(tt.budget * 6 / 10).Interval
+			use(tt.budget / 5)
+			high := check(tt.budget * 8 / 10).Interval
+			if low <= 0 || high <= low {
+				t.Errorf("intervals = %v at 60%% and %v at 80%%, want growing ones", low, high)
+			}
+			if err := l.Wait(cancelled); err != context.Canceled {
+				t.Errorf("Wait() right after a call = %v, want to wait for the interval", err)
+			}
+			clock.Add(high)
+			if err := l.Wait(context.Background()); err != nil {
+				t.Errorf("Wait() after the interval = %v", err)
+			}
+
+			// an exhausted budget holds the calls until they leave the window
+			use(tt.budget*2/10 - 1)
+			check(tt.budget)
+			if err := l.Wait(cancelled); err != context.Canceled {
+				t.Errorf("Wait() with an exhausted budget = %v, want to wait for the window", err)
+			}
+			clock.Add(tt.window)
+			if s := check(0); s.Interval != 0 {
+				t.Errorf("interval = %v once the window passed, want none", s.Interval)
+			}
+			if err := l.Wait(context.Background()); err != nil {
+				t.Errorf("Wait() once the window passed = %v", err)
+			}
+		})
+	}
+}
+
+func TestAdaptiveLimiterRateLimitHit(t *testing.T) {
+	// a HIT_RATE_LIMIT error with its Retry-After, after some time elapsed
+	type hit struct {
+		after, retryAfter time.Duration
+	}
+
+	tests := []struct {
+		name string
+		hits []hit
+		want time.Duration
+	}{
+		{
+			name: "retry after",
+			hits: []hit{{0, 30 * time.Second}},
+			want: 30 * time.Second,
+		},
+		{
+			name: "first backoff",
+			hits: []hit{{0, 0}},
+			want: time.Minute,
+		},
+		{
+			name: "doubled on consecutive hits",
+			hits: []hit{{0, 0}, {time.Minute, 0}, {2 * time.Minute, 0}},
+			want: 4 * time.Minute,
+		},
+		{
+			name: "capped",
+			hits: []hit{{0, 0}, {0, 0}, {0, 0}, {0, 0}, {0, 0}, {0, 0}, {0, 0}},
+			want: time.Hour,
+		},
+		{
+			name: "reset after a quiet period",
+			hits: []hit{{0, 0}, {time.Minute, 0}, {3 * time.Hour, 0}},
+			want: time.Minute,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			l, clock := newTestLimiter(DefaultQuota)
+			defer l.Close()
+
+			for _, h := range tt.hits {
+				clock.Add(h.after)
+				l.ObserveRateLimit(h.retryAfter)
+			}
+
+			s := l.Status()
+			if want := clock.Now().Add(tt.want); !s.BlockedUntil.Equal(want) {
+				t.Errorf("blocked until %v, want %v", s.BlockedUntil, want)
+			}
+
+			cancelled, cancel := context.WithCancel(context.Background())
+			cancel()
+			if err := l.Wait(cancelled); err != context.Canceled {
+				t.Errorf("Wait() while blocked = %v, want to wait", err)
+			}
+			clock.Add(tt.want)
+			if s := l.Status(); !s.BlockedUntil.IsZero() {
+				t.Errorf("blocked until %v after the delay, want unblocked", s.BlockedUntil)
+			}
+			if err := l.Wait(context.Background()); err != nil {
+				t.Errorf("Wait() after the delay = %v", err)
+			}
+		})
+	}
+}
+
+func TestAdaptiveLimiterZeroValue(t *testing.T) {
+	var l AdaptiveLimiter
+	if err := l.Wait(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	if s := l.Status(); s.HourlyUsed != 1 || s.HourlyRemaining != -1 || s.DailyRemaining != -1 {
+		t.Errorf("Status() = %+v, want 1 call and no budget", s)
+	}
+	if err := l.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if err := l.Wait(context.Background()); err != ErrLimiterClosed {
+		t.Errorf("Wait() after Close = %v, want ErrLimiterClosed", err)
+	}
+}
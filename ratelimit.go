@@ -0,0 +1,151 @@
+package eventbrite
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"golang.org/x/net/context"
+)
+
+// ErrLimiterClosed is returned by TokenBucket.Wait once the bucket is closed
+var ErrLimiterClosed = errors.New("eventbrite: rate limiter closed")
+
+// RateLimiter paces the requests of a client. Every attempt of a request waits for it, including
+// the retries. A limiter may be shared by several clients, typically those using the same token,
+// see WithRateLimiter.
+type RateLimiter interface {
+	// Wait blocks until a request may be sent, or until the context is done
+	Wait(ctx context.Context) error
+}
+
+// LimiterStats is a snapshot of the activity of a TokenBucket
+type LimiterStats struct {
+	// The number of tokens refilled per second
+	Rate float64
+	// The maximum number of tokens, the size of a burst
+	Burst int
+	// The number of tokens available right now, negative when callers are waiting
+	Available float64
+	// The number of requests let through since the bucket was created
+	Allowed int64
+	// The number of requests which had to wait for a token
+	Delayed int64
+	// The number of waits given up because their context was done
+	Cancelled int64
+	// The total time spent waiting for a token
+	TotalWait time.Duration
+}
+
+// TokenBucket is a RateLimiter refilling Rate tokens per second, up to Burst tokens. It starts
+// full, so up to Burst requests are sent at once. It runs no goroutine; Close only releases the
+// callers still waiting.
+type TokenBucket struct {
+	rate  float64
+	burst int
+
+	mu     sync.Mutex
+	tokens float64
+	last   time.Time
+	stats  LimiterStats
+	closed chan struct{}
+	once   sync.Once
+}
+
+// NewTokenBucket returns a full TokenBucket letting rate requests per second through, with
+// bursts of up to burst requests. A burst below one is raised to one. It panics when the rate
+// is not positive, use no limiter at all to disable rate limiting.
+func NewTokenBucket(rate float64, burst int) *TokenBucket {
+	if !(rate > 0) {
+		panic(fmt.Sprintf("eventbrite: non-positive rate %v for NewTokenBucket", rate))
+	}
+	if burst < 1 {
+		burst = 1
+	}
+	return &TokenBucket{
+		rate:   rate,
+		burst:  burst,
+		tokens: float64(burst),
+		last:   time.Now(),
+		closed: make(chan struct{}),
+	}
+}
+
+// Wait implements RateLimiter. Callers are served in the order they call Wait.
+func (b *TokenBucket) Wait(ctx context.Context) error {
+	select {
+	case <-b.closed:
+		return ErrLimiterClosed
+	default:
+	}
+
+	b.mu.Lock()
+	b.refill(time.Now())
+	// take the token right away, the deficit tells how long to wait for it
+	b.tokens--
+	b.stats.Allowed++
+	if b.tokens >= 0 {
+		b.mu.Unlock()
+		return nil
+	}
+	wait := time.Duration(-b.tokens / b.rate * float64(time.Second))
+	b.stats.Delayed++
+	b.mu.Unlock()
+
+	t := time.NewTimer(wait)
+	defer t.Stop()
+
+	select {
+	case <-t.C:
+		b.mu.Lock()
+		b.stats.TotalWait += wait
+		b.mu.Unlock()
+		return nil
+	case <-ctx.Done():
+		b.giveBack()
+		return ctx.Err()
+	case <-b.closed:
+		b.giveBack()
+		return ErrLimiterClosed
+	}
+}
+
+// giveBack returns the token taken by a wait which did not complete
+func (b *TokenBucket) giveBack() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.tokens++
+	b.stats.Allowed--
+	b.stats.Cancelled++
+}
+
+// refill adds the tokens accrued since the last refill, b.mu must be held
+func (b *TokenBucket) refill(now time.Time) {
+	b.tokens += now.Sub(b.last).Seconds() * b.rate
+	if b.tokens > float64(b.burst) {
+		b.tokens = float64(b.burst)
+	}
+	b.last = now
+}
+
+// Stats returns a snapshot of the activity of the bucket
+func (b *TokenBucket) Stats() LimiterStats {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.refill(time.Now())
+	s := b.stats
+	s.Rate = b.rate
+	s.Burst = b.burst
+	s.Available = b.tokens
+	return s
+}
+
+// Close releases the callers waiting for a token, they and every later caller of Wait get
+// ErrLimiterClosed
+func (b *TokenBucket) Close() error {
+	b.once.Do(func() { close(b.closed) })
+	return nil
+}
@@ -0,0 +1,131 @@
+package eventbrite
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"golang.org/x/net/context"
+)
+
+func TestTokenBucketWait(t *testing.T) {
+	tests := []struct {
+		name  string
+		rate  float64
+		burst int
+		// the number of waits, the last one gives up after timeout
+		waits   int
+		timeout time.Duration
+		wantErr error
+		want    LimiterStats
+	}{
+		{
+			name:  "burst",
+			rate:  1,
+			burst: 3,
+			waits: 3,
+			want:  LimiterStats{Allowed: 3},
+		},
+		{
+			name:  "delayed",
+			rate:  50,
+			burst: 1,
+			waits: 2,
+			want:  LimiterStats{Allowed: 2, Delayed: 1},
+		},
+		{
+			name:    "cancelled",
+			rate:    1,
+			burst:   2,
+			waits:   3,
+			timeout: 20 * time.Millisecond,
+			wantErr: context.DeadlineExceeded,
+			want:    LimiterStats{Allowed: 2, Delayed: 1, Cancelled: 1},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			b := NewTokenBucket(tt.rate, tt.burst)
+			defer b.Close()
+
+			var err error
+			for i := 0; i < tt.waits; i++ {
+				ctx := context.Background()
+				if i == tt.waits-1 && tt.timeout > 0 {
+					var cancel context.CancelFunc
+					ctx, cancel = context.WithTimeout(ctx, tt.timeout)
+					defer cancel()
+				}
+				err = b.Wait(ctx)
+			}
+			if err != tt.wantErr {
+				t.Fatalf("last Wait() = %v, want %v", err, tt.wantErr)
+			}
+
+			got := b.Stats()
+			if got.Allowed != tt.want.Allowed || got.Delayed != tt.want.Delayed || got.Cancelled != tt.want.Cancelled {
+				t.Errorf("Stats() = %+v, want %+v", got, tt.want)
+			}
+			if got.Rate != tt.rate || got.Burst != tt.burst {
+				t.Errorf("Stats() rate %v and burst %d, want %v and %d", got.Rate, got.Burst, tt.rate, tt.burst)
+			}
+			if tt.want.Delayed > tt.want.Cancelled && got.TotalWait <= 0 {
+				t.Errorf("Stats() total wait = %v, want the delay of the last wait", got.TotalWait)
+			}
+			if got.Available >= 1 {
+				t.Errorf("Stats() available = %v, want the burst used", got.Available)
+			}
+		})
+	}
+}
+
+func TestTokenBucketClose(t *testing.T) {
+	b := NewTokenBucket(0.001, 1)
+	if err := b.Wait(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+
+	done := make(chan error)
+	go func() {
+		done <- b.Wait(context.Background())
+	}()
+	// let the waiter block
+	time.Sleep(10 * time.Millisecond)
+	b.Close()
+
+	select {
+	case err := <-done:
+		if err != ErrLimiterClosed {
+			t.Errorf("waiting Wait() = %v, want ErrLimiterClosed", err)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("Close did not release the waiting caller")
+	}
+	if err := b.Wait(context.Background()); err != ErrLimiterClosed {
+		t.Errorf("Wait() after Close = %v, want ErrLimiterClosed", err)
+	}
+	if s := b.Stats(); s.Allowed != 1 || s.Cancelled != 1 {
+		t.Errorf("Stats() = %+v, want 1 allowed and 1 cancelled", s)
+	}
+	if err := b.Close(); err != nil {
+		t.Errorf("second Close() = %v", err)
+	}
+}
+
+func TestNewTokenBucketRate(t *testing.T) {
+	for _, rate := range []float64{0, -1, math.NaN()} {
+		func() {
+			defer func() {
+				if recover() == nil {
+					t.Errorf("NewTokenBucket(%v, 1) did not panic", rate)
+				}
+			}()
+			NewTokenBucket(rate, 1)
+		}()
+	}
+
+	if _, err := NewClient(WithToken("token"), WithRateLimit(-1)); err == nil {
+		t.Error("NewClient(WithRateLimit(-1)) succeeded, want an error")
+	}
+}
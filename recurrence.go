@@ -0,0 +1,425 @@
+package eventbrite
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Frequency is how often a Recurrence repeats
+type Frequency string
+
+// Frequencies supported by Recurrence
+const (
+	Weekly  Frequency = "WEEKLY"
+	Monthly Frequency = "MONTHLY"
+)
+
+// WeekdayNum is a day of the week. For a monthly rule, N restricts it to its nth occurrence in
+// the month: 1 for the first, -1 for the last, 0 for every one.
+type WeekdayNum struct {
+	N   int
+	Day time.Weekday
+}
+
+// Recurrence is an RRULE-style rule generating the dates of a repeating event series. Dates keep
+// the wall clock time of Start in its location across daylight saving changes.
+//
+//	loc, _ := time.LoadLocation("Europe/London")
+//	r := eventbrite.Recurrence{
+//		Freq:     eventbrite.Monthly,
+//		ByDay:    []eventbrite.WeekdayNum{{N: 1, Day: time.Tuesday}},
+//		Start:    time.Date(2024, 1, 2, 19, 0, 0, 0, loc),
+//		Duration: 2 * time.Hour,
+//		Count:    12,
+//	}
+//	dates, err := r.SeriesDates()
+//	series, err := clnt.EventSeriesCreate(ctx, &eventbrite.SeriesCreateEventRequest{SeriesParent: parent, CreateChildren: dates})
+type Recurrence struct {
+	Freq Frequency
+	// Repeat every Interval weeks or months, 1 when zero
+	Interval int
+	// The days of the week of the dates. A weekly rule defaults to the weekday of Start, a monthly
+	// one to the day of the month of Start, skipping the months without it.
+	ByDay []WeekdayNum
+	// The days of the month of the dates of a monthly rule, -1 for the last one. Months without
+	// such a day are skipped. It cannot be combined with ByDay.
+	ByMonthDay []int
+	// The number of dates generated before exclusions, unlimited when zero
+	Count int
+	// No date starts after Until, unlimited when zero. Count or Until must be set.
+	Until time.Time
+	// The days without a date, compared in the location of Start
+	Exclude []time.Time
+
+	// The start of the first date, no date is generated before it. Its location must be an
+	// Olson timezone, like America/New_York, rather than time.Local.
+	Start time.Time
+	// The length of every date
+	Duration time.Duration
+}
+
+// ParseRecurrence parses a rule in the RRULE format of RFC 5545, like
+// FREQ=WEEKLY;INTERVAL=2;BYDAY=MO,WE;UNTIL=20241231T235959Z or FREQ=MONTHLY;BYDAY=-1FR;COUNT=6.
+// Only the FREQ, INTERVAL, BYDAY, BYMONTHDAY, COUNT and UNTIL parts are supported. Excluded
+// dates, the EXDATE property of RFC 5545, are set through Exclude.
+func ParseRecurrence(rule string, start time.Time, duration time.Duration) (*Recurrence, error) {
+	r := &Recurrence{Start: start, Duration: duration}
+
+	for _, part := range strings.Split(strings.TrimPrefix(strings.TrimSpace(rule), "RRULE:"), ";") {
+		if part == "" {
+			continue
+		}
+		kv := strings.SplitN(part, "=", 2)
+		if len(kv) != 2 {
+			return nil, fmt.Errorf("eventbrite: invalid rule part %q", part)
+		}
+
+		var err error
+		switch key, value := strings.ToUpper(kv[0]), kv[1]; key {
+		case "FREQ":
+			r.Freq = Frequency(strings.ToUpper(value))
+		case "INTERVAL":
+			r.Interval, err = strconv.Atoi(value)
+		case "COUNT":
+			r.Count, err = strconv.Atoi(value)
+		case "UNTIL":
+			r.Until, err = parseUntil(value, start.Location())
+		case "BYDAY":
+			r.ByDay, err = parseByDay(value)
+		case "BYMONTHDAY":
+			r.ByMonthDay, err = parseByMonthDay(value)
+		default:
+			return nil, fmt.Errorf("eventbrite: unsupported rule part %s", key)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("eventbrite: invalid rule part %q: %v", part, err)
+		}
+	}
+
+	return r, r.validate()
+}
+
+var weekdays = map[string]time.Weekday{
+	"SU": time.Sunday,
+	"MO": time.Monday,
+	"TU": time.Tuesday,
+	"WE": time.Wednesday,
+	"TH": time.Thursday,
+	"FR": time.Friday,
+	"SA": time.Saturday,
+}
+
+func parseByDay(value string) ([]WeekdayNum, error) {
+	var res []WeekdayNum
+	for _, s := range strings.Split(value, ",") {
+		s = strings.ToUpper(strings.TrimSpace(s))
+		if len(s) < 2 {
+			return nil, fmt.Errorf("invalid day %q", s)
+		}
+
+		day, ok := weekdays[s[len(s)-2:]]
+		if !ok {
+			return nil, fmt.Errorf("invalid day %q", s)
+		}
+		var n int
+		if prefix := s[:len(s)-2]; prefix != "" {
+			var err error
+			if n, err = strconv.Atoi(prefix); err != nil {
+				return nil, fmt.Errorf("invalid day %q", s)
+			}
+		}
+		res = append(res, WeekdayNum{N: n, Day: day})
+	}
+	return res, nil
+}
+
+func parseByMonthDay(value string) ([]int, error) {
+	var res []int
+	for _, s := range strings.Split(value, ",") {
+		day, err := strconv.Atoi(strings.TrimSpace(s))
+		if err != nil {
+			return nil, fmt.Errorf("invalid day of month %q", s)
+		}
+		res = append(res, day)
+	}
+	return res, nil
+}
+
+// parseUntil parses a date-time in UTC, or a date including all of its day in loc
+func parseUntil(value string, loc *time.Location) (time.Time, error) {
+	if t, err := time.Parse("20060102T150405Z", value); err == nil {
+		return t, nil
+	}
+	t, err := time.ParseInLocation("20060102", value, loc)
+	if err != nil {
+		return time.Time{}, err
+	}
+	return t.AddDate(0, 0, 1).Add(-time.Second), nil
+}
+
+func (r *Recurrence) validate() error {
+	switch {
+	case r.Freq != Weekly && r.Freq != Monthly:
+		return fmt.Errorf("eventbrite: unsupported frequency %q", r.Freq)
+	case r.Interval < 0:
+		return errors.New("eventbrite: negative recurrence interval")
+	case r.Count < 0:
+		return errors.New("eventbrite: negative recurrence count")
+	case r.Count == 0 && r.Until.IsZero():
+		return errors.New("eventbrite: recurrence needs a count or an until time")
+	case r.Start.IsZero():
+		return errors.New("eventbrite: recurrence needs a start time")
+	case r.Start.Location() == time.Local:
+		return errors.New("eventbrite: recurrence start must be in an Olson timezone, not time.Local")
+	case r.Duration <= 0:
+		return errors.New("eventbrite: recurrence needs a positive duration")
+	}
+	for _, d := range r.ByDay {
+		if d.N < -5 || d.N > 5 || d.N != 0 && r.Freq == Weekly {
+			return fmt.Errorf("eventbrite: invalid occurrence %d of %s", d.N, d.Day)
+		}
+	}
+	switch {
+	case len(r.ByMonthDay) == 0:
+	case r.Freq != Monthly:
+		return errors.New("eventbrite: days of the month need a monthly recurrence")
+	case len(r.ByDay) > 0:
+		return errors.New("eventbrite: days of the month cannot be combined with days of the week")
+	}
+	for _, d := range r.ByMonthDay {
+		if d == 0 || d < -31 || d > 31 {
+			return fmt.Errorf("eventbrite: invalid day of the month %d", d)
+		}
+	}
+	return nil
+}
+
+// Dates returns the start of every date of the rule, in the location of Start
+func (r *Recurrence) Dates() ([]time.Time, error) {
+	if err := r.validate(); err != nil {
+		return nil, err
+	}
+
+	interval := r.Interval
+	if interval == 0 {
+		interval = 1
+	}
+
+	excluded := map[string]bool{}
+	for _, t := range r.Exclude {
+		excluded[t.In(r.Start.Location()).Format("2006-01-02")] = true
+	}
+
+	var res []time.Time
+	generated := 0
+	for period := 0; ; period += interval {
+		candidates, first := r.period(period)
+		if !r.Until.IsZero() && first.After(r.Until) {
+			return res, nil
+		}
+
+		for _, t := range candidates {
+			switch {
+			case t.Before(r.Start):
+				continue
+			case !r.Until.IsZero() && t.After(r.Until), r.Count > 0 && generated == r.Count:
+				return res, nil
+			}
+
+			generated++
+			if !excluded[t.Format("2006-01-02")] {
+				res = append(res, t)
+			}
+		}
+	}
+}
+
+// period returns the sorted candidate dates of the nth week or month after the one of Start,
+// along with the first instant of that week or month
+func (r *Recurrence) period(n int) ([]time.Time, time.Time) {
+	s := r.Start
+	at := func(year int, month time.Month, day int) time.Time {
+		return time.Date(year, month, day, s.Hour(), s.Minute(), s.Second(), 0, s.Location())
+	}
+
+	var res []time.Time
+	var first time.Time
+	if r.Freq == Weekly {
+		// weeks start on Monday, as with the default WKST of RFC 5545
+		monday := at(s.Year(), s.Month(), s.Day()-(int(s.Weekday())+6)%7+7*n)
+		first = time.Date(monday.Year(), monday.Month(), monday.Day(), 0, 0, 0, 0, s.Location())
+
+		days := r.ByDay
+		if len(days) == 0 {
+			days = []WeekdayNum{{Day: s.Weekday()}}
+		}
+		for _, d := range days {
+			res = append(res, at(monday.Year(), monday.Month(), monday.Day()+(int(d.Day)+6)%7))
+		}
+	} else {
+		first = time.Date(s.Year(), s.Month()+time.Month(n), 1, 0, 0, 0, 0, s.Location())
+		year, month := first.Year(), first.Month()
+		daysIn := time.Date(year, month+1, 0, 0, 0, 0, 0, s.Location()).Day()
+
+		if len(r.ByDay) == 0 && len(r.ByMonthDay) == 0 && s.Day() <= daysIn {
+			res = append(res, at(year, month, s.Day()))
+		}
+		for _, d := range r.ByMonthDay {
+			if d < 0 {
+				d += daysIn + 1
+			}
+			if d >= 1 && d <= daysIn {
+				res = append(res, at(year, month, d))
+			}
+		}
+		for _, d := range r.ByDay {
+			// day of the month of the first such weekday, and number of such weekdays
+			firstDay := 1 + (int(d.Day)-int(first.Weekday())+7)%7
+			count := (daysIn-firstDay)/7 + 1
+			for i := 1; i <= count; i++ {
+				if d.N == 0 || d.N == i || d.N == i-count-1 {
+					res = append(res, at(year, month, firstDay+7*(i-1)))
+				}
+			}
+		}
+	}
+
+	sort.Slice(res, func(i, j int) bool { return res[i].Before(res[j]) })
+	return dedupTimes(res), first
+}
+
+func dedupTimes(times []time.Time) []time.Time {
+	res := times[:0]
+	for i, t := range times {
+		if i == 0 || !t.Equal(times[i-1]) {
+			res = append(res, t)
+		}
+	}
+	return res
+}
+
+// SeriesDates returns the dates of the rule, ready to be sent as the children of a series
+func (r *Recurrence) SeriesDates() ([]SeriesDate, error) {
+	dates, err := r.Dates()
+	if err != nil {
+		return nil, err
+	}
+
+	tz := r.Start.Location().String()
+	res := make([]SeriesDate, len(dates))
+	for i, t := range dates {
+		res[i] = SeriesDate{
+			Start: EventTime{Utc: DateTime{Time: t.UTC()}, Timezone: tz},
+			End:   EventTime{Utc: DateTime{Time: t.Add(r.Duration).UTC()}, Timezone: tz},
+		}
+	}
+	return res, nil
+}
+
+// DiffSeries returns the request turning the current dates of a series, as listed by
+// EventSeriesEventsIterator, into the desired ones. A current date is kept when a desired one
+// starts at the same time, and updated when its end or timezone differs or when a desired date
+// starts another time on the same day. The other current dates are deleted and the other
+// desired dates created.
+//
+//	var current []eventbrite.Event
+//	err := clnt.EventSeriesEventsIterator(ctx, "123", nil).Walk(func(e eventbrite.Event) error {
+//		current = append(current, e)
+//		return nil
+//	})
+//	req, err := eventbrite.DiffSeries(current, dates)
+//	if !req.Empty() {
+//		_, err = clnt.EventSeriesCUD(ctx, "123", req)
+//	}
+func DiffSeries(current []Event, desired []SeriesDate) (*SeriesCUREventRequest, error) {
+	type child struct {
+		id    string
+		start time.Time
+		date  SeriesDate
+	}
+
+	children := make([]*child, 0, len(current))
+	for _, e := range current {
+		start, err := time.Parse("2006-01-02T15:04:05Z", e.Start.Utc)
+		if err != nil {
+			return nil, fmt.Errorf("eventbrite: invalid start of event %s: %v", e.Id, err)
+		}
+		end, err := time.Parse("2006-01-02T15:04:05Z", e.End.Utc)
+		if err != nil {
+			return nil, fmt.Errorf("eventbrite: invalid end of event %s: %v", e.Id, err)
+		}
+		children = append(children, &child{
+			id:    e.Id,
+			start: start,
+			date: SeriesDate{
+				Start: EventTime{Utc: DateTime{Time: start}, Timezone: e.Start.Timezone},
+				End:   EventTime{Utc: DateTime{Time: end}, Timezone: e.End.Timezone},
+			},
+		})
+	}
+	sort.Slice(children, func(i, j int) bool { return children[i].start.Before(children[j].start) })
+
+	wanted := append([]SeriesDate(nil), desired...)
+	sort.SliceStable(wanted, func(i, j int) bool { return wanted[i].Start.Utc.Time.Before(wanted[j].Start.Utc.Time) })
+
+	req := &SeriesCUREventRequest{UpdateChildren: map[string]SeriesDate{}}
+	matched := make([]bool, len(wanted))
+	update := func(c *child, d SeriesDate) {
+		if !sameSeriesDate(c.date, d) {
+			req.UpdateChildren[c.id] = d
+		}
+	}
+
+	// same start first, then same day
+	for i, d := range wanted {
+		for j, c := range children {
+			if c != nil && c.start.Equal(d.Start.Utc.Time) {
+				update(c, d)
+				matched[i], children[j] = true, nil
+				break
+			}
+		}
+	}
+	for i, d := range wanted {
+		if matched[i] {
+			continue
+		}
+		loc, err := time.LoadLocation(d.Start.Timezone)
+		if err != nil {
+			return nil, fmt.Errorf("eventbrite: invalid timezone %q: %v", d.Start.Timezone, err)
+		}
+		day := d.Start.Utc.Time.In(loc).Format("2006-01-02")
+
+		for j, c := range children {
+			if c != nil && c.start.In(loc).Format("2006-01-02") == day {
+				update(c, d)
+				matched[i], children[j] = true, nil
+				break
+			}
+		}
+	}
+
+	for i, d := range wanted {
+		if !matched[i] {
+			req.CreateChildren = append(req.CreateChildren, d)
+		}
+	}
+	for _, c := range children {
+		if c != nil {
+			req.DeleteChildren = append(req.DeleteChildren, c.id)
+		}
+	}
+	if len(req.UpdateChildren) == 0 {
+		req.UpdateChildren = nil
+	}
+	return req, nil
+}
+
+func sameSeriesDate(a, b SeriesDate) bool {
+	return a.Start.Utc.Time.Equal(b.Start.Utc.Time) && a.Start.Timezone == b.Start.Timezone &&
+		a.End.Utc.Time.Equal(b.End.Utc.Time) && a.End.Timezone == b.End.Timezone
+}
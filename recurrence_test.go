@@ -0,0 +1,249 @@
+package eventbrite
+
+import (
+	"sort"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestParseRecurrence(t *testing.T) {
+	london, err := time.LoadLocation("Europe/London")
+	if err != nil {
+		t.Fatal(err)
+	}
+	// a Monday
+	monday := time.Date(2024, 1, 1, 10, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name    string
+		rule    string
+		start   time.Time
+		exclude []time.Time
+		want    []string
+		wantErr string
+	}{
+		{
+			name:  "weekly interval and days",
+			rule:  "FREQ=WEEKLY;INTERVAL=2;BYDAY=MO,WE;COUNT=5",
+			start: monday,
+			want: []string{
+				"2024-01-01T10:00:00Z", "2024-01-03T10:00:00Z",
+				"2024-01-15T10:00:00Z", "2024-01-17T10:00:00Z",
+				"2024-01-29T10:00:00Z",
+			},
+		},
+		{
+			name:  "monthly last friday",
+			rule:  "RRULE:FREQ=MONTHLY;BYDAY=-1FR;COUNT=3",
+			start: time.Date(2024, 1, 1, 18, 0, 0, 0, time.UTC),
+			want:  []string{"2024-01-26T18:00:00Z", "2024-02-23T18:00:00Z", "2024-03-29T18:00:00Z"},
+		},
+		{
+			name:  "monthly day 31 skips short months",
+			rule:  "FREQ=MONTHLY;BYMONTHDAY=31;COUNT=4",
+			start: time.Date(2024, 1, 1, 18, 0, 0, 0, time.UTC),
+			want: []string{
+				"2024-01-31T18:00:00Z", "2024-03-31T18:00:00Z",
+				"2024-05-31T18:00:00Z", "2024-07-31T18:00:00Z",
+			},
+		},
+		{
+			name:  "monthly last day",
+			rule:  "FREQ=MONTHLY;BYMONTHDAY=-1;COUNT=2",
+			start: time.Date(2024, 2, 1, 18, 0, 0, 0, time.UTC),
+			want:  []string{"2024-02-29T18:00:00Z", "2024-03-31T18:00:00Z"},
+		},
+		{
+			name:  "until time",
+			rule:  "FREQ=WEEKLY;UNTIL=20240115T100000Z",
+			start: monday,
+			want:  []string{"2024-01-01T10:00:00Z", "2024-01-08T10:00:00Z", "2024-01-15T10:00:00Z"},
+		},
+		{
+			name:  "until date includes its day",
+			rule:  "FREQ=WEEKLY;UNTIL=20240115",
+			start: monday,
+			want:  []string{"2024-01-01T10:00:00Z", "2024-01-08T10:00:00Z", "2024-01-15T10:00:00Z"},
+		},
+		{
+			name:    "excluded dates count",
+			rule:    "FREQ=WEEKLY;COUNT=3",
+			start:   monday,
+			exclude: []time.Time{time.Date(2024, 1, 8, 0, 0, 0, 0, time.UTC)},
+			want:    []string{"2024-01-01T10:00:00Z", "2024-01-15T10:00:00Z"},
+		},
+		{
+			name:  "daylight saving keeps the wall clock",
+			rule:  "FREQ=WEEKLY;COUNT=3",
+			start: time.Date(2024, 3, 28, 19, 0, 0, 0, london),
+			want: []string{
+				"2024-03-28T19:00:00Z", "2024-04-04T19:00:00+01:00", "2024-04-11T19:00:00+01:00",
+			},
+		},
+		{
+			name:    "unsupported frequency",
+			rule:    "FREQ=DAILY;COUNT=3",
+			start:   monday,
+			wantErr: "unsupported frequency",
+		},
+		{
+			name:    "unbounded",
+			rule:    "FREQ=WEEKLY",
+			start:   monday,
+			wantErr: "count or an until",
+		},
+		{
+			name:    "weekly occurrence",
+			rule:    "FREQ=WEEKLY;BYDAY=1MO;COUNT=3",
+			start:   monday,
+			wantErr: "invalid occurrence",
+		},
+		{
+			name:    "weekly day of the month",
+			rule:    "FREQ=WEEKLY;BYMONTHDAY=1;COUNT=3",
+			start:   monday,
+			wantErr: "monthly recurrence",
+		},
+		{
+			name:    "unsupported part",
+			rule:    "FREQ=WEEKLY;COUNT=3;BYSETPOS=1",
+			start:   monday,
+			wantErr: "unsupported rule part",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r, err := ParseRecurrence(tt.rule, tt.start, time.Hour)
+			if err == nil {
+				r.Exclude = tt.exclude
+				var dates []time.Time
+				if dates, err = r.Dates(); err == nil {
+					var got []string
+					for _, d := range dates {
+						got = append(got, d.Format(time.RFC3339))
+					}
+					if strings.Join(got, ",") != strings.Join(tt.want, ",") {
+						t.Errorf("Dates() = %v, want %v", got, tt.want)
+					}
+				}
+			}
+			if tt.wantErr == "" && err != nil || tt.wantErr != "" && (err == nil || !strings.Contains(err.Error(), tt.wantErr)) {
+				t.Errorf("error = %v, want %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestDiffSeries(t *testing.T) {
+	child := func(id, start, end string) Event {
+		return Event{
+			Id:    id,
+			Start: DatetimeTz{Utc: start, Timezone: "Europe/London"},
+			End:   DatetimeTz{Utc: end, Timezone: "Europe/London"},
+		}
+	}
+	date := func(start, end string) SeriesDate {
+		parse := func(s string) EventTime {
+			t, err := time.Parse(time.RFC3339, s)
+			if err != nil {
+				panic(err)
+			}
+			return EventTime{Utc: DateTime{Time: t}, Timezone: "Europe/London"}
+		}
+		return SeriesDate{Start: parse(start), End: parse(end)}
+	}
+
+	current := []Event{
+		child("3", "2024-01-15T10:00:00Z", "2024-01-15T12:00:00Z"),
+		child("1", "2024-01-01T10:00:00Z", "2024-01-01T12:00:00Z"),
+		child("2", "2024-01-08T10:00:00Z", "2024-01-08T12:00:00Z"),
+	}
+
+	tests := []struct {
+		name    string
+		desired []SeriesDate
+		// the start of the created dates
+		create []string
+		// the IDs of the updated events, along with their new start
+		update map[string]string
+		delete []string
+	}{
+		{
+			name: "unchanged",
+			desired: []SeriesDate{
+				date("2024-01-01T10:00:00Z", "2024-01-01T12:00:00Z"),
+				date("2024-01-08T10:00:00Z", "2024-01-08T12:00:00Z"),
+				date("2024-01-15T10:00:00Z", "2024-01-15T12:00:00Z"),
+			},
+		},
+		{
+			name: "longer date",
+			desired: []SeriesDate{
+				date("2024-01-01T10:00:00Z", "2024-01-01T12:00:00Z"),
+				date("2024-01-08T10:00:00Z", "2024-01-08T13:00:00Z"),
+				date("2024-01-15T10:00:00Z", "2024-01-15T12:00:00Z"),
+			},
+			update: map[string]string{"2": "2024-01-08T10:00:00Z"},
+		},
+		{
+			name: "moved on the same day",
+			desired: []SeriesDate{
+				date("2024-01-01T10:00:00Z", "2024-01-01T12:00:00Z"),
+				date("2024-01-08T14:00:00Z", "2024-01-08T16:00:00Z"),
+				date("2024-01-15T10:00:00Z", "2024-01-15T12:00:00Z"),
+			},
+			update: map[string]string{"2": "2024-01-08T14:00:00Z"},
+		},
+		{
+			name: "shifted a week",
+			desired: []SeriesDate{
+				date("2024-01-22T10:00:00Z", "2024-01-22T12:00:00Z"),
+				date("2024-01-08T10:00:00Z", "2024-01-08T12:00:00Z"),
+				date("2024-01-15T10:00:00Z", "2024-01-15T12:00:00Z"),
+			},
+			create: []string{"2024-01-22T10:00:00Z"},
+			delete: []string{"1"},
+		},
+		{
+			name:   "cleared",
+			delete: []string{"1", "2", "3"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req, err := DiffSeries(current, tt.desired)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			var create []string
+			for _, d := range req.CreateChildren {
+				create = append(create, d.Start.Utc.Time.Format(time.RFC3339))
+			}
+			if strings.Join(create, ",") != strings.Join(tt.create, ",") {
+				t.Errorf("created %v, want %v", create, tt.create)
+			}
+
+			if len(req.UpdateChildren) != len(tt.update) {
+				t.Errorf("updated %v, want %v", req.UpdateChildren, tt.update)
+			}
+			for id, start := range tt.update {
+				if d, ok := req.UpdateChildren[id]; !ok || d.Start.Utc.Time.Format(time.RFC3339) != start {
+					t.Errorf("update of %s = %+v, want a start at %s", id, d, start)
+				}
+			}
+
+			deleted := append([]string(nil), req.DeleteChildren...)
+			sort.Strings(deleted)
+			if strings.Join(deleted, ",") != strings.Join(tt.delete, ",") {
+				t.Errorf("deleted %v, want %v", deleted, tt.delete)
+			}
+			if got, want := req.Empty(), len(tt.create)+len(tt.update)+len(tt.delete) == 0; got != want {
+				t.Errorf("Empty() = %v, want %v", got, want)
+			}
+		})
+	}
+}
@@ -1,11 +1,90 @@
 package eventbrite
 
-import "golang.org/x/net/context"
+import (
+	"encoding/json"
+	"sort"
+	"strconv"
+	"time"
+
+	"golang.org/x/net/context"
+)
+
+// ReportGroupBy is a field the report data can be broken down on
+type ReportGroupBy string
+
+// Fields supported by the group_by parameter of the reports
+const (
+	GroupByPaymentMethod            ReportGroupBy = "payment_method"
+	GroupByPaymentMethodApplication ReportGroupBy = "payment_method_application"
+	GroupByTicket                   ReportGroupBy = "ticket"
+	GroupByTicketApplication        ReportGroupBy = "ticket_application"
+	GroupByCurrency                 ReportGroupBy = "currency"
+	GroupByEventCurrency            ReportGroupBy = "event_currency"
+	GroupByReservedSection          ReportGroupBy = "reserved_section"
+	GroupByEvent                    ReportGroupBy = "event"
+	GroupByEventTicket              ReportGroupBy = "event_ticket"
+	GroupByEventApplication         ReportGroupBy = "event_application"
+	GroupByCountry                  ReportGroupBy = "country"
+	GroupByCity                     ReportGroupBy = "city"
+	GroupByState                    ReportGroupBy = "state"
+	GroupBySource                   ReportGroupBy = "source"
+	GroupByZone                     ReportGroupBy = "zone"
+	GroupByLocation                 ReportGroupBy = "location"
+	GroupByAccessLevel              ReportGroupBy = "access_level"
+	GroupByDeviceName               ReportGroupBy = "device_name"
+	GroupBySalesChannelLvl1         ReportGroupBy = "sales_channel_lvl_1"
+	GroupBySalesChannelLvl2         ReportGroupBy = "sales_channel_lvl_2"
+	GroupBySalesChannelLvl3         ReportGroupBy = "sales_channel_lvl_3"
+)
+
+// DateFacet is the date aggregation level of the report data
+type DateFacet string
+
+// Levels supported by the date_facet parameter of the reports
+const (
+	DateFacetFifteen  DateFacet = "fifteen"
+	DateFacetHour     DateFacet = "hour"
+	DateFacetDay      DateFacet = "day"
+	DateFacetEventDay DateFacet = "event_day"
+	DateFacetWeek     DateFacet = "week"
+	DateFacetMonth    DateFacet = "month"
+	DateFacetYear     DateFacet = "year"
+	DateFacetNone     DateFacet = "none"
+)
+
+// ReportFilter narrows down the data of a report
+//
+// https://www.eventbrite.com/developer/v3/endpoints/reports/#ebapi-parameters
+type ReportFilter struct {
+	// Only report on these ticket classes
+	TicketIDs []string
+	// Only report on amounts in this currency, the API supports a single one
+	Currency string
+}
+
+// MarshalJSON encodes the filter as the filter_by dictionary, like {"ticket_ids": [1234], "currencies": ["USD"]}
+func (f ReportFilter) MarshalJSON() ([]byte, error) {
+	v := map[string]interface{}{}
+	if len(f.TicketIDs) > 0 {
+		ids := make([]interface{}, len(f.TicketIDs))
+		for i, id := range f.TicketIDs {
+			ids[i] = id
+			if _, err := strconv.ParseInt(id, 10, 64); err == nil {
+				ids[i] = json.Number(id)
+			}
+		}
+		v["ticket_ids"] = ids
+	}
+	if f.Currency != "" {
+		v["currencies"] = []string{f.Currency}
+	}
+	return json.Marshal(v)
+}
 
 // https://www.eventbrite.com/developer/v3/endpoints/reports/#ebapi-parameters
 type ReportRequest struct {
 	// List of public event IDs to report on
-	EventIds []interface{} `json:"event_ids"`
+	EventIds []string `json:"event_ids"`
 	// Event status to filter down results by (Valid choices are: all, live, or ended)
 	EventStatus string `json:"event_status"`
 	// Optional start date to query
@@ -15,73 +94,308 @@ type ReportRequest struct {
 	// Time period to provide aggregation for in units of the selected date_facet.
 	// For example, if date_facet=hour, then period=3 returns 3 hours worth of data
 	// from the current time in the event timezone. Day is the default choice if no date_facet
-	Period int `json:"period"`
-	// Optional filters for sales/attendees data formatted as: {“ticket_ids”: [1234, 5678],
-	// “currencies”: [“USD”],...}NOTE: currently only filter_by ticket_ids and one currency are supported.
+	Period int `json:"period" url:",omitempty"`
+	// Optional filters for sales/attendees data. NOTE: currently only filter_by ticket_ids and
+	// one currency are supported.
 	//
 	// https://www.eventbrite.com/developer/v3/response_formats/basic/#ebapi-dictionary
-	FilterBy interface{} `json:"filter_by"`
-	// Optional field to group data on (Valid choices are: payment_method, payment_method_application,
-	// ticket, ticket_application, currency, event_currency, reserved_section, event, event_ticket,
-	// event_application, country, city, state, source, zone, location, access_level, device_name,
-	// sales_channel_lvl_1, sales_channel_lvl_2, or sales_channel_lvl_3)
-	GroupBy string `json:"group_by"`
+	FilterBy *ReportFilter `json:"filter_by" url:"filter_by,json,omitempty"`
+	// Optional field to group data on
+	GroupBy ReportGroupBy `json:"group_by"`
 	// Optional date aggregation level to return data for. Day is the default choice. Monthly aggregation
 	// is represented by the first of the month. Weekly aggregation is represented by the ending Sunday of
-	// the week, where a week is defined as Monday-Sunday. (Valid choices are: fifteen, hour, day, event_day,
-	// week, month, year, or none)
-	DateFacet string `json:"date_facet"`
+	// the week, where a week is defined as Monday-Sunday.
+	DateFacet DateFacet `json:"date_facet"`
 	// Optional timezone. If unspecified picks the TZ of the first event
 	Timezone string `json:"timezone"`
 }
 
+// ReportAttendees is the request of the attendees report, which takes the same parameters as
+// the sales report
+//
 // https://www.eventbrite.com/developer/v3/endpoints/reports/#ebapi-id1
-type ReportAttendees struct {
-	// List of public event IDs to report on
-	EventIds []interface{} `json:"event_ids"`
-	// Event status to filter down results by (Valid choices are: all, live, or ended)
-	EventStatus string `json:"event_status"`
-	// Optional start date to query
-	StartDate string `json:"start_date"`
-	// Optional end date to query
-	EndDate string `json:"end_date"`
-	// Time period to provide aggregation for in units of the selected date_facet.
-	// For example, if date_facet=hour, then period=3 returns 3 hours worth of data
-	// from the current time in the event timezone. Day is the default choice if no date_facet
-	Period int `json:"period"`
-	// Optional filters for sales/attendees data formatted as: {“ticket_ids”: [1234, 5678],
-	// “currencies”: [“USD”],...}NOTE: currently only filter_by ticket_ids and one currency are supported.
-	//
-	// https://www.eventbrite.com/developer/v3/response_formats/basic/#ebapi-dictionary
-	FilterBy interface{} `json:"filter_by"`
-	// Optional field to group data on (Valid choices are: payment_method, payment_method_application,
-	// ticket, ticket_application, currency, event_currency, reserved_section, event, event_ticket,
-	// event_application, country, city, state, source, zone, location, access_level, device_name,
-	// sales_channel_lvl_1, sales_channel_lvl_2, or sales_channel_lvl_3)
-	GroupBy string `json:"group_by"`
-	// Optional date aggregation level to return data for. Day is the default choice. Monthly aggregation
-	// is represented by the first of the month. Weekly aggregation is represented by the ending Sunday of
-	// the week, where a week is defined as Monday-Sunday. (Valid choices are: fifteen, hour, day, event_day,
-	// week, month, year, or none)
-	DateFacet string `json:"date_facet"`
-	// Optional timezone. If unspecified picks the TZ of the first event
+type ReportAttendees = ReportRequest
+
+// NewReportRequest returns a request reporting on the given events, which can be refined with
+// the chained setters below
+//
+//	req := eventbrite.NewReportRequest("123", "456").
+//		Between(start, end).
+//		Group(eventbrite.GroupByTicket, eventbrite.DateFacetWeek)
+func NewReportRequest(eventIDs ...string) *ReportRequest {
+	return &ReportRequest{EventIds: eventIDs}
+}
+
+// Between restricts the report to the dates from start to end, a zero time leaves the bound open
+func (r *ReportRequest) Between(start, end time.Time) *ReportRequest {
+	r.StartDate, r.EndDate = "", ""
+	if !start.IsZero() {
+		r.StartDate = start.Format("2006-01-02")
+	}
+	if !end.IsZero() {
+		r.EndDate = end.Format("2006-01-02")
+	}
+	return r
+}
+
+// Group breaks the report data down on a field and a date aggregation level
+func (r *ReportRequest) Group(by ReportGroupBy, facet DateFacet) *ReportRequest {
+	r.GroupBy = by
+	r.DateFacet = facet
+	return r
+}
+
+// Filter restricts the report to some ticket classes or a currency
+func (r *ReportRequest) Filter(f ReportFilter) *ReportRequest {
+	r.FilterBy = &f
+	return r
+}
+
+// ReportTotals are the aggregated figures of a report, a data point or a breakdown. The sales
+// report fills the amounts and the quantity, the attendees report the attendee count.
+type ReportTotals struct {
+	// The currency of the amounts, empty when mixing currencies
+	Currency string `json:"currency"`
+	// Amount paid by the attendees
+	Gross float64 `json:"gross"`
+	// Amount left to the organizer once fees are paid
+	Net float64 `json:"net"`
+	// Fees charged on the sales
+	Fees float64 `json:"fees"`
+	// Number of tickets sold
+	Quantity int `json:"quantity"`
+	// Number of attendees
+	NumAttendees int `json:"num_attendees"`
+}
+
+// add sums the figures of o into t
+func (t *ReportTotals) add(o ReportTotals) {
+	if *t == (ReportTotals{}) {
+		t.Currency = o.Currency
+	} else if t.Currency != o.Currency {
+		t.Currency = ""
+	}
+	t.Gross += o.Gross
+	t.Net += o.Net
+	t.Fees += o.Fees
+	t.Quantity += o.Quantity
+	t.NumAttendees += o.NumAttendees
+}
+
+// ReportMetric is one of the figures of ReportTotals
+type ReportMetric string
+
+// Metrics of the reports
+const (
+	MetricGross        ReportMetric = "gross"
+	MetricNet          ReportMetric = "net"
+	MetricFees         ReportMetric = "fees"
+	MetricQuantity     ReportMetric = "quantity"
+	MetricNumAttendees ReportMetric = "num_attendees"
+)
+
+// Value returns the figure of the given metric, 0 for an unknown one
+func (t ReportTotals) Value(m ReportMetric) float64 {
+	switch m {
+	case MetricGross:
+		return t.Gross
+	case MetricNet:
+		return t.Net
+	case MetricFees:
+		return t.Fees
+	case MetricQuantity:
+		return float64(t.Quantity)
+	case MetricNumAttendees:
+		return float64(t.NumAttendees)
+	}
+	return 0
+}
+
+// ReportBreakdown are the totals of one value of the group_by field
+type ReportBreakdown struct {
+	// The value of the group_by field, like a ticket class name or a country
+	Name   string       `json:"name"`
+	Totals ReportTotals `json:"totals"`
+}
+
+// ReportPoint is the data of a single date of the report timeseries
+type ReportPoint struct {
+	// The start of the period, in the report timezone
+	Date string `json:"date"`
+	// The start of the period, localized
+	DateLocalized string       `json:"date_localized"`
+	Totals        ReportTotals `json:"totals"`
+	// The totals per value of the group_by field, empty when the report is not grouped
+	Breakdown []ReportBreakdown `json:"breakdown"`
+}
+
+// Time parses the date of the point
+func (p ReportPoint) Time() (time.Time, error) {
+	return time.Parse(time.RFC3339, p.Date)
+}
+
+// Report is the response of the sales and attendees reports
+//
+// https://www.eventbrite.com/developer/v3/endpoints/reports/
+type Report struct {
+	// The timezone of the dates
 	Timezone string `json:"timezone"`
+	// The events reported on
+	EventIDs []string `json:"event_ids"`
+	// The totals of the whole report
+	Totals ReportTotals `json:"totals"`
+	// The timeseries, one point per date_facet period
+	Data []ReportPoint `json:"data"`
+}
+
+// MergeReports combines reports on distinct events, like the reports of batches of events, into
+// a single one. Points of the same date are summed, as well as breakdowns of the same name.
+func MergeReports(reports ...*Report) *Report {
+	res := &Report{}
+	points := map[string]int{}
+	for _, r := range reports {
+		if r == nil {
+			continue
+		}
+		if res.Timezone == "" {
+			res.Timezone = r.Timezone
+		}
+		res.EventIDs = append(res.EventIDs, r.EventIDs...)
+		res.Totals.add(r.Totals)
+
+		for _, p := range r.Data {
+			i, ok := points[p.Date]
+			if !ok {
+				i = len(res.Data)
+				points[p.Date] = i
+				res.Data = append(res.Data, ReportPoint{Date: p.Date, DateLocalized: p.DateLocalized})
+			}
+			res.Data[i].Totals.add(p.Totals)
+			res.Data[i].Breakdown = mergeBreakdown(res.Data[i].Breakdown, p.Breakdown)
+		}
+	}
+
+	sort.SliceStable(res.Data, func(i, j int) bool { return res.Data[i].Date < res.Data[j].Date })
+	return res
+}
+
+func mergeBreakdown(dst, src []ReportBreakdown) []ReportBreakdown {
+	for _, b := range src {
+		found := false
+		for i := range dst {
+			if dst[i].Name == b.Name {
+				dst[i].Totals.add(b.Totals)
+				found = true
+				break
+			}
+		}
+		if !found {
+			dst = append(dst, ReportBreakdown{Name: b.Name, Totals: b.Totals})
+		}
+	}
+	return dst
+}
+
+// ReportPivot is a metric of a report laid out as a table, with a row per date and a column
+// per breakdown name
+type ReportPivot struct {
+	Metric ReportMetric
+	// The dates of the rows
+	Dates []string
+	// The breakdown names of the columns, in order of appearance
+	Names []string
+	// Values[i][j] is the metric of Names[j] at Dates[i]
+	Values [][]float64
+}
+
+// Pivot lays out a metric of the report as a table of dates by breakdown names. Reports that
+// are not grouped have a single column named after the metric.
+func (r *Report) Pivot(m ReportMetric) *ReportPivot {
+	res := &ReportPivot{Metric: m}
+	columns := map[string]int{}
+	for _, p := range r.Data {
+		for _, b := range p.Breakdown {
+			if _, ok := columns[b.Name]; !ok {
+				columns[b.Name] = len(res.Names)
+				res.Names = append(res.Names, b.Name)
+			}
+		}
+	}
+	grouped := len(res.Names) > 0
+	if !grouped {
+		res.Names = []string{string(m)}
+	}
+
+	for _, p := range r.Data {
+		row := make([]float64, len(res.Names))
+		if grouped {
+			for _, b := range p.Breakdown {
+				row[columns[b.Name]] += b.Totals.Value(m)
+			}
+		} else {
+			row[0] = p.Totals.Value(m)
+		}
+		res.Dates = append(res.Dates, p.Date)
+		res.Values = append(res.Values, row)
+	}
+	return res
+}
+
+// ReportRow is a flat line of a report timeseries, meant for exports
+type ReportRow struct {
+	Date string
+	// The breakdown name, empty for the totals of the date
+	Name string
+	ReportTotals
+}
+
+// ReportHeader names the fields of ReportRow.Record
+var ReportHeader = []string{"date", "name", "currency", "gross", "net", "fees", "quantity", "num_attendees"}
+
+// Record returns the row as strings, in the order of ReportHeader
+func (r ReportRow) Record() []string {
+	return []string{
+		r.Date,
+		r.Name,
+		r.Currency,
+		strconv.FormatFloat(r.Gross, 'f', -1, 64),
+		strconv.FormatFloat(r.Net, 'f', -1, 64),
+		strconv.FormatFloat(r.Fees, 'f', -1, 64),
+		strconv.Itoa(r.Quantity),
+		strconv.Itoa(r.NumAttendees),
+	}
+}
+
+// Rows flattens the timeseries into one row per date and breakdown name, or one row per date
+// when the report is not grouped
+func (r *Report) Rows() []ReportRow {
+	var rows []ReportRow
+	for _, p := range r.Data {
+		if len(p.Breakdown) == 0 {
+			rows = append(rows, ReportRow{Date: p.Date, ReportTotals: p.Totals})
+			continue
+		}
+		for _, b := range p.Breakdown {
+			rows = append(rows, ReportRow{Date: p.Date, Name: b.Name, ReportTotals: b.Totals})
+		}
+	}
+	return rows
 }
 
 // ReportSales returns a response of the aggregate sales data
 //
 // https://www.eventbrite.com/developer/v3/endpoints/reports/#ebapi-get-reports-sales
-func (c *Client) ReportSales(ctx context.Context, req *ReportRequest) (interface{}, error) {
-	var v interface{}
+func (c *Client) ReportSales(ctx context.Context, req *ReportRequest) (*Report, error) {
+	res := new(Report)
 
-	return v, c.getJSON(ctx, "/reports/sales/", req, &v)
+	return res, c.getJSON(ctx, "ReportSales", "/reports/sales/", req, res)
 }
 
-// ReportSales returns a response of the aggregate attendees data
+// ReportAttendees returns a response of the aggregate attendees data
 //
 // https://www.eventbrite.com/developer/v3/endpoints/reports/#ebapi-get-reports-attendees
-func (c *Client) ReportAttendees(ctx context.Context, req *ReportAttendees) (interface{}, error) {
-	var v interface{}
+func (c *Client) ReportAttendees(ctx context.Context, req *ReportAttendees) (*Report, error) {
+	res := new(Report)
 
-	return v, c.getJSON(ctx, "/reports/attendees/", req, &v)
+	return res, c.getJSON(ctx, "ReportAttendees", "/reports/attendees/", req, res)
 }
@@ -0,0 +1,149 @@
+package eventbrite
+
+import (
+	"errors"
+	"fmt"
+	"math/rand"
+	"net/http"
+	"strconv"
+	"time"
+
+	"golang.org/x/net/context"
+)
+
+// DefaultRetryPolicy is a reasonable retry policy for long running jobs
+var DefaultRetryPolicy = RetryPolicy{
+	MaxAttempts: 4,
+	MinBackoff:  500 * time.Millisecond,
+	MaxBackoff:  30 * time.Second,
+}
+
+// RetryPolicy describes how a request failing with HTTP 429, a 5xx status or a network
+// error is retried. GET and DELETE requests are idempotent and always retried, POST
+// requests only when RetryPost is set. A response asking to retry after more than
+// MaxBackoff is not retried.
+type RetryPolicy struct {
+	// Maximum number of attempts, including the first one. A value lower than 2 disables retries
+	MaxAttempts int
+	// Backoff before the first retry, doubled on every following one
+	MinBackoff time.Duration
+	// Upper bound of the backoff between two attempts
+	MaxBackoff time.Duration
+	// Retry POST requests as well. Eventbrite may have applied a POST which failed with
+	// a 5xx status, so retrying it may create duplicates
+	RetryPost bool
+}
+
+// RetryError is returned when a request still fails after being attempted more than once
+type RetryError struct {
+	// Number of attempts made, including the first one
+	Attempts int
+	// The error of the last attempt
+	Err error
+}
+
+func (e *RetryError) Error() string {
+	return fmt.Sprintf("eventbrite: giving up after %d attempts: %s", e.Attempts, e.Err)
+}
+
+// Unwrap returns the error of the last attempt
+func (e *RetryError) Unwrap() error {
+	return e.Err
+}
+
+// WithRetryPolicy configures the client to retry failed requests following the given policy
+func WithRetryPolicy(policy RetryPolicy) ClientOption {
+	return func(c *Client) error {
+		if policy.MinBackoff <= 0 {
+			policy.MinBackoff = DefaultRetryPolicy.MinBackoff
+		}
+		if policy.MaxBackoff < policy.MinBackoff {
+			policy.MaxBackoff = policy.MinBackoff
+		}
+		c.retryPolicy = policy
+		return nil
+	}
+}
+
+// shouldRetry reports whether the attempt-th attempt of a request sent with the given method
+// may be retried after resp or err
+func (p RetryPolicy) shouldRetry(method string, attempt int, resp *Response, err error) bool {
+	if attempt >= p.MaxAttempts {
+		return false
+	}
+	if method == http.MethodPost && !p.RetryPost {
+		return false
+	}
+	if resp == nil {
+		// no answer was received, only a failure of the transport is worth another attempt
+		var te *transportError
+		return errors.As(err, &te)
+	}
+
+	switch resp.StatusCode {
+	case http.StatusTooManyRequests, http.StatusInternalServerError, http.StatusBadGateway,
+		http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		// waiting longer than MaxBackoff is left to the caller
+		d, ok := retryAfter(resp.Header.Get("Retry-After"))
+		return !ok || d <= p.MaxBackoff
+	}
+	return false
+}
+
+// backoff returns how long to wait after the attempt-th attempt. The exponential backoff is
+// jittered to spread concurrent clients and is superseded by a Retry-After header if any.
+// Neither exceeds MaxBackoff.
+func (p RetryPolicy) backoff(attempt int, resp *Response) time.Duration {
+	if resp != nil {
+		if d, ok := retryAfter(resp.Header.Get("Retry-After")); ok {
+			if d > p.MaxBackoff {
+				d = p.MaxBackoff
+			}
+			return d
+		}
+	}
+
+	d := p.MinBackoff << uint(attempt-1)
+	if d > p.MaxBackoff || d <= 0 {
+		d = p.MaxBackoff
+	}
+	return d/2 + time.Duration(rand.Int63n(int64(d/2)+1))
+}
+
+// retryAfter parses a Retry-After header value, given either in seconds or as an HTTP date
+func retryAfter(v string) (time.Duration, bool) {
+	if v == "" {
+		return 0, false
+	}
+	if s, err := strconv.Atoi(v); err == nil && s >= 0 {
+		return time.Duration(s) * time.Second, true
+	}
+	if t, err := http.ParseTime(v); err == nil {
+		d := time.Until(t)
+		if d < 0 {
+			d = 0
+		}
+		return d, true
+	}
+	return 0, false
+}
+
+func sleep(ctx context.Context, d time.Duration) error {
+	t := time.NewTimer(d)
+	defer t.Stop()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-t.C:
+		return nil
+	}
+}
+
+// withAttempts wraps err into a RetryError when the request was attempted more than once
+func withAttempts(attempts int, err error) error {
+	if err == nil || attempts <= 1 {
+		return err
+	}
+	return &RetryError{Attempts: attempts, Err: err}
+}
@@ -0,0 +1,152 @@
+package eventbrite
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"golang.org/x/net/context"
+)
+
+func TestRetryPolicyShouldRetry(t *testing.T) {
+	policy := RetryPolicy{MaxAttempts: 3, MinBackoff: time.Second, MaxBackoff: time.Minute}
+	status := func(code int, retryAfter string) *Response {
+		resp := &Response{StatusCode: code, Header: http.Header{}}
+		if retryAfter != "" {
+			resp.Header.Set("Retry-After", retryAfter)
+		}
+		return resp
+	}
+
+	tests := []struct {
+		name      string
+		policy    RetryPolicy
+		method    string
+		attempt   int
+		resp      *Response
+		err       error
+		wantRetry bool
+	}{
+		{"service unavailable", policy, http.MethodGet, 1, status(503, ""), nil, true},
+		{"too many requests", policy, http.MethodDelete, 2, status(429, ""), nil, true},
+		{"last attempt", policy, http.MethodGet, 3, status(503, ""), nil, false},
+		{"not found", policy, http.MethodGet, 1, status(404, ""), nil, false},
+		{"post", policy, http.MethodPost, 1, status(503, ""), nil, false},
+		{"retried post", RetryPolicy{MaxAttempts: 3, MaxBackoff: time.Minute, RetryPost: true}, http.MethodPost, 1, status(503, ""), nil, true},
+		{"short retry-after", policy, http.MethodGet, 1, status(429, "30"), nil, true},
+		{"retry-after beyond max backoff", policy, http.MethodGet, 1, status(429, "3600"), nil, false},
+		{"transport error", policy, http.MethodGet, 1, nil, &transportError{err: http.ErrHandlerTimeout}, true},
+		{"request error", policy, http.MethodGet, 1, nil, http.ErrMissingFile, false},
+		{"disabled", RetryPolicy{}, http.MethodGet, 1, status(503, ""), nil, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.policy.shouldRetry(tt.method, tt.attempt, tt.resp, tt.err); got != tt.wantRetry {
+				t.Errorf("shouldRetry() = %v, want %v", got, tt.wantRetry)
+			}
+		})
+	}
+}
+
+func TestRetryPolicyBackoff(t *testing.T) {
+	policy := RetryPolicy{MaxAttempts: 5, MinBackoff: time.Second, MaxBackoff: 10 * time.Second}
+
+	tests := []struct {
+		name       string
+		attempt    int
+		retryAfter string
+		min, max   time.Duration
+	}{
+		{"first", 1, "", 500 * time.Millisecond, time.Second},
+		{"doubled", 2, "", time.Second, 2 * time.Second},
+		{"capped", 10, "", 5 * time.Second, 10 * time.Second},
+		{"overflow", 80, "", 5 * time.Second, 10 * time.Second},
+		{"retry-after", 1, "7", 7 * time.Second, 7 * time.Second},
+		{"retry-after capped", 1, "3600", 10 * time.Second, 10 * time.Second},
+		{"retry-after date", 1, time.Now().Add(time.Hour).UTC().Format(http.TimeFormat), 10 * time.Second, 10 * time.Second},
+		{"invalid retry-after", 1, "soon", 500 * time.Millisecond, time.Second},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resp := &Response{StatusCode: http.StatusServiceUnavailable, Header: http.Header{}}
+			if tt.retryAfter != "" {
+				resp.Header.Set("Retry-After", tt.retryAfter)
+			}
+			for i := 0; i < 20; i++ {
+				if d := policy.backoff(tt.attempt, resp); d < tt.min || d > tt.max {
+					t.Fatalf("backoff(%d) = %v, want within [%v, %v]", tt.attempt, d, tt.min, tt.max)
+				}
+			}
+		})
+	}
+}
+
+func TestClientRetries(t *testing.T) {
+	tests := []struct {
+		name         string
+		statuses     []int
+		retryAfter   string
+		wantAttempts int
+		wantErr      error
+	}{
+		{name: "success", wantAttempts: 1},
+		{name: "retried", statuses: []int{503, 502}, wantAttempts: 3},
+		{name: "exhausted", statuses: []int{503, 503, 503}, wantAttempts: 3, wantErr: &Error{Status: 503}},
+		{name: "not retried", statuses: []int{404}, wantAttempts: 1, wantErr: ErrNotFound},
+		{name: "retry-after beyond max backoff", statuses: []int{429}, retryAfter: "3600", wantAttempts: 1, wantErr: ErrHitRateLimit},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			attempts := 0
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				attempts++
+				if attempts > len(tt.statuses) {
+					fmt.Fprint(w, `{"id":"42"}`)
+					return
+				}
+				status := tt.statuses[attempts-1]
+				if tt.retryAfter != "" {
+					w.Header().Set("Retry-After", tt.retryAfter)
+				}
+				w.WriteHeader(status)
+				switch status {
+				case 404:
+					fmt.Fprint(w, `{"error":"NOT_FOUND","status_code":404}`)
+				case 429:
+					fmt.Fprint(w, `{"error":"HIT_RATE_LIMIT","status_code":429}`)
+				}
+			}))
+			defer srv.Close()
+
+			clnt, err := NewClient(WithToken("token"), WithBaseURL(srv.URL), WithRateLimit(0),
+				WithRetryPolicy(RetryPolicy{MaxAttempts: 3, MinBackoff: time.Millisecond, MaxBackoff: time.Second}))
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			_, err = clnt.EventGet(context.Background(), "42")
+			if attempts != tt.wantAttempts {
+				t.Errorf("attempts = %d, want %d", attempts, tt.wantAttempts)
+			}
+			if tt.wantErr == nil {
+				if err != nil {
+					t.Fatal(err)
+				}
+				return
+			}
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("error = %v, want %v", err, tt.wantErr)
+			}
+			var retryErr *RetryError
+			if errors.As(err, &retryErr) != (tt.wantAttempts > 1) {
+				t.Errorf("error = %v, want a RetryError: %v", err, tt.wantAttempts > 1)
+			}
+		})
+	}
+}
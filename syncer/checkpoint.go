@@ -0,0 +1,172 @@
+package syncer
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"golang.org/x/net/context"
+)
+
+// Cursor is the position of a sync. Objects changed before ChangedSince have been emitted,
+// Seen keeps the version of the objects emitted within the overlap window so that they are
+// not emitted again by the next pass.
+type Cursor struct {
+	// The changed time of the most recent object emitted
+	ChangedSince time.Time `json:"changed_since"`
+	// The changed time of the objects emitted within the overlap window, keyed by ID
+	Seen map[string]time.Time `json:"seen"`
+}
+
+// CheckpointStore persists the cursors of the syncers, keyed by source
+type CheckpointStore interface {
+	// Load returns the cursor saved under key, or nil when there is none
+	Load(ctx context.Context, key string) (*Cursor, error)
+	// Save stores the cursor under key
+	Save(ctx context.Context, key string, c *Cursor) error
+}
+
+// MemoryStore is a CheckpointStore keeping the cursors in memory, a sync restarts from scratch
+// along with the process
+type MemoryStore struct {
+	mu      sync.Mutex
+	cursors map[string]Cursor
+}
+
+// NewMemoryStore returns an empty MemoryStore
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{cursors: map[string]Cursor{}}
+}
+
+// Load implements CheckpointStore
+func (s *MemoryStore) Load(_ context.Context, key string) (*Cursor, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	c, ok := s.cursors[key]
+	if !ok {
+		return nil, nil
+	}
+	c.Seen = copySeen(c.Seen)
+	return &c, nil
+}
+
+// Save implements CheckpointStore
+func (s *MemoryStore) Save(_ context.Context, key string, c *Cursor) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.cursors[key] = Cursor{ChangedSince: c.ChangedSince, Seen: copySeen(c.Seen)}
+	return nil
+}
+
+func copySeen(seen map[string]time.Time) map[string]time.Time {
+	res := make(map[string]time.Time, len(seen))
+	for id, t := range seen {
+		res[id] = t
+	}
+	return res
+}
+
+// FileStore is a CheckpointStore keeping every cursor in a single JSON file. The file is
+// written to disk and replaced atomically on each save, so a crash never leaves a partial
+// checkpoint behind.
+type FileStore struct {
+	path string
+	mu   sync.Mutex
+}
+
+// NewFileStore returns a FileStore reading and writing the file at path, which is created on
+// the first save
+func NewFileStore(path string) *FileStore {
+	return &FileStore{path: path}
+}
+
+// Load implements CheckpointStore
+func (s *FileStore) Load(_ context.Context, key string) (*Cursor, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	cursors, err := s.read()
+	if err != nil {
+		return nil, err
+	}
+
+	c, ok := cursors[key]
+	if !ok {
+		return nil, nil
+	}
+	return &c, nil
+}
+
+// Save implements CheckpointStore
+func (s *FileStore) Save(_ context.Context, key string, c *Cursor) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	cursors, err := s.read()
+	if err != nil {
+		return err
+	}
+	cursors[key] = *c
+
+	b, err := json.MarshalIndent(cursors, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	tmp, err := ioutil.TempFile(filepath.Dir(s.path), filepath.Base(s.path)+".tmp")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.Write(b); err != nil {
+		tmp.Close()
+		return err
+	}
+	// flush the content before the rename makes it visible, otherwise a crash may leave an
+	// empty checkpoint in place of the previous one
+	if err := tmp.Sync(); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	if err := os.Rename(tmp.Name(), s.path); err != nil {
+		return err
+	}
+	return syncDir(filepath.Dir(s.path))
+}
+
+// syncDir flushes the directory entries of dir, so that a rename into it survives a crash
+func syncDir(dir string) error {
+	d, err := os.Open(dir)
+	if err != nil {
+		return err
+	}
+	defer d.Close()
+
+	return d.Sync()
+}
+
+func (s *FileStore) read() (map[string]Cursor, error) {
+	cursors := map[string]Cursor{}
+
+	b, err := ioutil.ReadFile(s.path)
+	if os.IsNotExist(err) {
+		return cursors, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(b, &cursors); err != nil {
+		return nil, fmt.Errorf("syncer: corrupt checkpoint %s: %v", s.path, err)
+	}
+	return cursors, nil
+}
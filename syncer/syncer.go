@@ -0,0 +1,216 @@
+// Package syncer incrementally mirrors the attendees and orders of an event, or of every event
+// owned by a user or organization, relying on the changed_since filter of the list endpoints.
+//
+// Each pass only pulls the objects changed since the cursor saved by the previous one, drops
+// those already emitted and sends the others as upserts on a channel. Cursors are persisted
+// through a CheckpointStore, so a restarted process resumes where it stopped.
+//
+//	s := syncer.NewEventSyncer(clnt, "123", syncer.NewFileStore("eventbrite.json"))
+//	upserts := make(chan syncer.Upsert)
+//	go func() {
+//		for u := range upserts {
+//			// handle me
+//		}
+//	}()
+//	err := s.Run(ctx, upserts)
+package syncer
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/apzuk3/go-eventbrite"
+
+	"golang.org/x/net/context"
+)
+
+// Kind is the kind of object synced
+type Kind string
+
+// Kinds of objects supported by the syncer
+const (
+	KindAttendee Kind = "attendees"
+	KindOrder    Kind = "orders"
+)
+
+const (
+	// DefaultInterval is the time between two passes of Run
+	DefaultInterval = 5 * time.Minute
+	// DefaultOverlap is how far before the cursor each pass starts, to catch objects whose
+	// change was committed after a more recent one had been listed
+	DefaultOverlap = time.Minute
+)
+
+// changedSinceLayout is the format of the changed_since parameter
+const changedSinceLayout = "2006-01-02T15:04:05Z"
+
+// Upsert is an object created or changed since it was last emitted. Only the field matching
+// Kind is set.
+type Upsert struct {
+	Kind Kind
+	// The object ID
+	ID string
+	// When the object was last changed
+	Changed time.Time
+
+	Attendee *eventbrite.Attendee
+	Order    *eventbrite.Order
+}
+
+const (
+	scopeEvent = "event"
+	scopeUser  = "user"
+)
+
+// Syncer pulls the objects changed since its last pass. The exported fields must be set before
+// the first pass.
+type Syncer struct {
+	client *eventbrite.Client
+	store  CheckpointStore
+	scope  string
+	id     string
+
+	// Kinds lists the kinds of objects synced, both attendees and orders by default
+	Kinds []Kind
+	// Interval is the time between two passes of Run
+	Interval time.Duration
+	// Overlap is how far before the cursor each pass starts
+	Overlap time.Duration
+}
+
+// NewEventSyncer returns a Syncer for the attendees and orders of a single event
+func NewEventSyncer(client *eventbrite.Client, eventID string, store CheckpointStore) *Syncer {
+	return newSyncer(client, scopeEvent, eventID, store)
+}
+
+// NewUserSyncer returns a Syncer for the attendees and orders of every event owned by a user
+// or an organization. The ID may be "me" for the owner of the client token.
+func NewUserSyncer(client *eventbrite.Client, userID string, store CheckpointStore) *Syncer {
+	return newSyncer(client, scopeUser, userID, store)
+}
+
+func newSyncer(client *eventbrite.Client, scope, id string, store CheckpointStore) *Syncer {
+	return &Syncer{
+		client:   client,
+		store:    store,
+		scope:    scope,
+		id:       id,
+		Kinds:    []Kind{KindAttendee, KindOrder},
+		Interval: DefaultInterval,
+		Overlap:  DefaultOverlap,
+	}
+}
+
+// Key returns the key the cursor of the given kind is saved under
+func (s *Syncer) Key(kind Kind) string {
+	return s.scope + ":" + s.id + ":" + string(kind)
+}
+
+// Run syncs every Interval until the context is done or a pass fails. The channel is not
+// closed on return.
+func (s *Syncer) Run(ctx context.Context, out chan<- Upsert) error {
+	for {
+		if err := s.SyncOnce(ctx, out); err != nil {
+			return err
+		}
+
+		t := time.NewTimer(s.Interval)
+		select {
+		case <-ctx.Done():
+			t.Stop()
+			return ctx.Err()
+		case <-t.C:
+		}
+	}
+}
+
+// SyncOnce runs a single pass over every kind, sending the upserts on out. The cursor of a kind
+// is saved once all its pages are listed, so a failed pass is replayed by the next one and
+// objects are emitted at least once.
+func (s *Syncer) SyncOnce(ctx context.Context, out chan<- Upsert) error {
+	for _, kind := range s.Kinds {
+		if err := s.sync(ctx, kind, out); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (s *Syncer) sync(ctx context.Context, kind Kind, out chan<- Upsert) error {
+	key := s.Key(kind)
+
+	c, err := s.store.Load(ctx, key)
+	if err != nil {
+		return err
+	}
+	if c == nil {
+		c = &Cursor{}
+	}
+	if c.Seen == nil {
+		c.Seen = map[string]time.Time{}
+	}
+
+	var since string
+	if !c.ChangedSince.IsZero() {
+		since = c.ChangedSince.Add(-s.Overlap).UTC().Format(changedSinceLayout)
+	}
+
+	next := c.ChangedSince
+	err = s.list(ctx, kind, since, func(u Upsert) error {
+		if seen, ok := c.Seen[u.ID]; ok && !u.Changed.After(seen) {
+			return nil
+		}
+
+		select {
+		case out <- u:
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+
+		c.Seen[u.ID] = u.Changed
+		if u.Changed.After(next) {
+			next = u.Changed
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	c.ChangedSince = next
+	for id, changed := range c.Seen {
+		if changed.Before(next.Add(-s.Overlap)) {
+			delete(c.Seen, id)
+		}
+	}
+	return s.store.Save(ctx, key, c)
+}
+
+// list walks the objects of the given kind changed since the given time, every object when empty
+func (s *Syncer) list(ctx context.Context, kind Kind, since string, emit func(Upsert) error) error {
+	switch kind {
+	case KindAttendee:
+		var it *eventbrite.Iterator[eventbrite.Attendee]
+		if s.scope == scopeEvent {
+			it = s.client.EventAttendeesIterator(ctx, s.id, &eventbrite.EventGetAttendees{ChangedSince: since})
+		} else {
+			it = s.client.UserEventAttendeesIterator(ctx, s.id, &eventbrite.UserEventAttendeesRequest{ChangedSince: since})
+		}
+
+		return it.Walk(func(a eventbrite.Attendee) error {
+			return emit(Upsert{Kind: kind, ID: a.ID, Changed: a.Changed.Time, Attendee: &a})
+		})
+	case KindOrder:
+		var it *eventbrite.Iterator[eventbrite.Order]
+		if s.scope == scopeEvent {
+			it = s.client.EventOrdersIterator(ctx, s.id, &eventbrite.EventGetOrders{ChangedSince: since})
+		} else {
+			it = s.client.UserEventOrdersIterator(ctx, s.id, &eventbrite.UserEventOrdersRequest{ChangedSince: since})
+		}
+
+		return it.Walk(func(o eventbrite.Order) error {
+			return emit(Upsert{Kind: kind, ID: o.ID, Changed: o.Changed.Time, Order: &o})
+		})
+	}
+	return fmt.Errorf("syncer: unknown kind %q", kind)
+}
@@ -0,0 +1,104 @@
+package syncer
+
+import (
+	"path/filepath"
+	"sort"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/apzuk3/go-eventbrite"
+	"github.com/apzuk3/go-eventbrite/eventbritetest"
+
+	"golang.org/x/net/context"
+)
+
+// TestSyncOnce runs consecutive passes through a new Syncer each, so that the cursor only
+// survives through the store
+func TestSyncOnce(t *testing.T) {
+	memory := NewMemoryStore()
+	path := filepath.Join(t.TempDir(), "checkpoint.json")
+
+	tests := []struct {
+		name  string
+		store func() CheckpointStore
+	}{
+		{name: "memory", store: func() CheckpointStore { return memory }},
+		{name: "file", store: func() CheckpointStore { return NewFileStore(path) }},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			srv := eventbritetest.NewServer("token")
+			defer srv.Close()
+			clnt, err := srv.Client()
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			start := time.Date(2026, 7, 1, 18, 0, 0, 0, time.UTC)
+			at := func(d time.Duration) eventbrite.DateTime {
+				return eventbrite.DateTime{Time: start.Add(d)}
+			}
+
+			eventID := srv.AddEvent(eventbrite.Event{})
+			srv.AddAttendee(eventbrite.Attendee{ID: "a1", EventID: eventID, Changed: at(0)})
+			srv.AddAttendee(eventbrite.Attendee{ID: "a2", EventID: eventID, Changed: at(time.Hour)})
+			srv.AddOrder(eventbrite.Order{ID: "o1", EventID: eventID, Changed: at(0)})
+
+			pass := func() []string {
+				t.Helper()
+
+				out := make(chan Upsert, 10)
+				if err := NewEventSyncer(clnt, eventID, tt.store()).SyncOnce(context.Background(), out); err != nil {
+					t.Fatal(err)
+				}
+				close(out)
+
+				var ids []string
+				for u := range out {
+					ids = append(ids, u.ID)
+				}
+				sort.Strings(ids)
+				return ids
+			}
+			cursor := func(kind Kind) time.Time {
+				t.Helper()
+
+				c, err := tt.store().Load(context.Background(), NewEventSyncer(clnt, eventID, nil).Key(kind))
+				if err != nil {
+					t.Fatal(err)
+				}
+				if c == nil {
+					t.Fatalf("no %s cursor saved", kind)
+				}
+				return c.ChangedSince
+			}
+
+			if got := pass(); strings.Join(got, ",") != "a1,a2,o1" {
+				t.Errorf("first pass emitted %v, want every object", got)
+			}
+			if got := cursor(KindAttendee); !got.Equal(start.Add(time.Hour)) {
+				t.Errorf("attendees cursor = %v, want the most recent change", got)
+			}
+
+			// a2 is listed again within the overlap window, but it did not change since
+			if got := pass(); len(got) != 0 {
+				t.Errorf("pass without changes emitted %v, want nothing", got)
+			}
+
+			// an object changed before the cursor is not listed anymore
+			srv.AddAttendee(eventbrite.Attendee{ID: "a0", EventID: eventID, Changed: at(-time.Hour)})
+			srv.AddAttendee(eventbrite.Attendee{ID: "a1", EventID: eventID, Changed: at(2 * time.Hour)})
+			if got := pass(); strings.Join(got, ",") != "a1" {
+				t.Errorf("pass after a change emitted %v, want a1", got)
+			}
+			if got := cursor(KindAttendee); !got.Equal(start.Add(2 * time.Hour)) {
+				t.Errorf("attendees cursor = %v, want the most recent change", got)
+			}
+			if got := cursor(KindOrder); !got.Equal(start) {
+				t.Errorf("orders cursor = %v, want %v", got, start)
+			}
+		})
+	}
+}
@@ -26,7 +26,7 @@ type Countries struct {
 func (c *Client) Timezones(ctx context.Context) (*Timezones, error) {
 	res := new(Timezones)
 
-	return res, c.getJSON(ctx, "/system/timezones/", nil, res)
+	return res, c.getJSON(ctx, "Timezones", "/system/timezones/", nil, res)
 }
 
 // Timezones returns a single page response with a key of regions, containing a list of regions
@@ -35,7 +35,7 @@ func (c *Client) Timezones(ctx context.Context) (*Timezones, error) {
 func (c *Client) Regions(ctx context.Context) (*Regions, error) {
 	res := new(Regions)
 
-	return res, c.getJSON(ctx, "/system/regions/", nil, res)
+	return res, c.getJSON(ctx, "Regions", "/system/regions/", nil, res)
 }
 
 // Timezones returns a single page response with a key of countries, containing a list of countries
@@ -44,5 +44,5 @@ func (c *Client) Regions(ctx context.Context) (*Regions, error) {
 func (c *Client) Countries(ctx context.Context) (*Countries, error) {
 	res := new(Countries)
 
-	return res, c.getJSON(ctx, "/system/regions/", nil, res)
+	return res, c.getJSON(ctx, "Countries", "/system/countries/", nil, res)
 }
@@ -0,0 +1,189 @@
+// Package telemetry instruments an Eventbrite client with OpenTelemetry. Every call gets a span
+// named after the Client method making it, like EventGet or UserOrders, and is counted in the
+// request count and latency metrics.
+//
+//	obs, err := telemetry.New(nil, nil)
+//	if err != nil {
+//		// handle me
+//	}
+//	clnt, err := eventbrite.NewClient(eventbrite.WithToken(token), eventbrite.WithCallObserver(obs))
+//
+// Spans carry the path template, the HTTP status, the error key, the number of retries and the
+// time spent waiting for the rate limiter. Metrics are recorded with the operation, the method,
+// the path template, the HTTP status and the error key as attributes.
+package telemetry
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/apzuk3/go-eventbrite"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/trace"
+	"golang.org/x/net/context"
+)
+
+// ScopeName is the instrumentation scope of the spans and metrics
+const ScopeName = "github.com/apzuk3/go-eventbrite/telemetry"
+
+// Attribute keys of the spans and metrics
+const (
+	// The Client method making the call
+	AttrOperation = attribute.Key("eventbrite.operation")
+	// The HTTP method
+	AttrMethod = attribute.Key("http.request.method")
+	// The API path with its IDs replaced by {id}
+	AttrRoute = attribute.Key("url.template")
+	// The HTTP status of the last response
+	AttrStatusCode = attribute.Key("http.response.status_code")
+	// The error key of a failed call, like NOT_FOUND, or the kind of error when Eventbrite did
+	// not answer
+	AttrError = attribute.Key("error.type")
+	// The number of retries, the attempts after the first one
+	AttrRetries = attribute.Key("http.request.resend_count")
+	// The time spent waiting for the rate limiter, in seconds
+	AttrLimiterWait = attribute.Key("eventbrite.rate_limiter.wait")
+)
+
+// Names of the metrics
+const (
+	// The number of calls
+	MetricRequests = "eventbrite.client.requests"
+	// The duration of the calls in seconds, retries and rate limiting included
+	MetricDuration = "eventbrite.client.duration"
+	// The time the calls spent waiting for the rate limiter in seconds
+	MetricLimiterWait = "eventbrite.client.rate_limiter.wait"
+)
+
+// Observer is an eventbrite.CallObserver recording the calls of a client as spans and metrics
+type Observer struct {
+	tracer      trace.Tracer
+	requests    metric.Int64Counter
+	duration    metric.Float64Histogram
+	limiterWait metric.Float64Histogram
+}
+
+// New returns an Observer creating its spans with tp and its metrics with mp. A nil provider
+// stands for the global one of the otel package.
+func New(tp trace.TracerProvider, mp metric.MeterProvider) (*Observer, error) {
+	if tp == nil {
+		tp = otel.GetTracerProvider()
+	}
+	if mp == nil {
+		mp = otel.GetMeterProvider()
+	}
+
+	o := &Observer{tracer: tp.Tracer(ScopeName)}
+	meter := mp.Meter(ScopeName)
+
+	var err error
+	o.requests, err = meter.Int64Counter(MetricRequests,
+		metric.WithDescription("Number of calls to the Eventbrite API"),
+		metric.WithUnit("{call}"))
+	if err != nil {
+		return nil, err
+	}
+	o.duration, err = meter.Float64Histogram(MetricDuration,
+		metric.WithDescription("Duration of the calls to the Eventbrite API, retries included"),
+		metric.WithUnit("s"))
+	if err != nil {
+		return nil, err
+	}
+	o.limiterWait, err = meter.Float64Histogram(MetricLimiterWait,
+		metric.WithDescription("Time the calls to the Eventbrite API spent waiting for the rate limiter"),
+		metric.WithUnit("s"))
+	if err != nil {
+		return nil, err
+	}
+	return o, nil
+}
+
+type startKey struct{}
+
+// StartCall implements eventbrite.CallObserver
+func (o *Observer) StartCall(ctx context.Context, call *eventbrite.Call) context.Context {
+	ctx, _ = o.tracer.Start(ctx, spanName(call),
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(
+			AttrOperation.String(call.Operation),
+			AttrMethod.String(call.Method),
+			AttrRoute.String(call.Route),
+		),
+	)
+	return context.WithValue(ctx, startKey{}, time.Now())
+}
+
+// EndCall implements eventbrite.CallObserver
+func (o *Observer) EndCall(ctx context.Context, call *eventbrite.Call, err error) {
+	var elapsed time.Duration
+	if start, ok := ctx.Value(startKey{}).(time.Time); ok {
+		elapsed = time.Since(start)
+	}
+
+	// the outcome of the call, on top of the attributes set by StartCall
+	var outcome []attribute.KeyValue
+	if call.StatusCode != 0 {
+		outcome = append(outcome, AttrStatusCode.Int(call.StatusCode))
+	}
+	if err != nil {
+		outcome = append(outcome, AttrError.String(errorType(err, call.StatusCode)))
+	}
+
+	span := trace.SpanFromContext(ctx)
+	span.SetAttributes(outcome...)
+	if call.Attempts > 1 {
+		span.SetAttributes(AttrRetries.Int(call.Attempts - 1))
+	}
+	span.SetAttributes(AttrLimiterWait.Float64(call.LimiterWait.Seconds()))
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	span.End()
+
+	attrs := append([]attribute.KeyValue{
+		AttrOperation.String(call.Operation),
+		AttrMethod.String(call.Method),
+		AttrRoute.String(call.Route),
+	}, outcome...)
+	set := metric.WithAttributeSet(attribute.NewSet(attrs...))
+	o.requests.Add(ctx, 1, set)
+	o.duration.Record(ctx, elapsed.Seconds(), set)
+	o.limiterWait.Record(ctx, call.LimiterWait.Seconds(), set)
+}
+
+// spanName returns the name of the span of a call: its operation, or its method and path
+// template when it is not made by a Client method
+func spanName(call *eventbrite.Call) string {
+	if call.Operation != "" {
+		return call.Operation
+	}
+	return call.Method + " " + call.Route
+}
+
+// errorType returns a low cardinality description of err: the Eventbrite error key, the HTTP
+// status when there is none, or the kind of error when Eventbrite did not answer
+func errorType(err error, status int) string {
+	var apiErr *eventbrite.Error
+	switch {
+	case errors.As(err, &apiErr) && apiErr.Err != "":
+		return apiErr.Err
+	case errors.As(err, &apiErr):
+		return strconv.Itoa(apiErr.Status)
+	case status >= http.StatusBadRequest:
+		return strconv.Itoa(status)
+	case errors.Is(err, context.Canceled):
+		return "canceled"
+	case errors.Is(err, context.DeadlineExceeded):
+		return "timeout"
+	case errors.Is(err, eventbrite.ErrLimiterClosed):
+		return "limiter_closed"
+	}
+	return "_OTHER"
+}
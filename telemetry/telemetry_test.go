@@ -0,0 +1,197 @@
+package telemetry
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/apzuk3/go-eventbrite"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"golang.org/x/net/context"
+)
+
+// newServer answers the n-th request with the n-th status, 200 once they are exhausted
+func newServer(t *testing.T, statuses ...int) *httptest.Server {
+	n := 0
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		status := http.StatusOK
+		if n < len(statuses) {
+			status = statuses[n]
+		}
+		n++
+
+		w.WriteHeader(status)
+		switch status {
+		case http.StatusOK:
+			fmt.Fprint(w, `{"id":"42"}`)
+		case http.StatusNotFound:
+			fmt.Fprint(w, `{"error":"NOT_FOUND","status_code":404}`)
+		default:
+			fmt.Fprintf(w, `{"error":"INTERNAL_ERROR","status_code":%d}`, status)
+		}
+	}))
+	t.Cleanup(srv.Close)
+	return srv
+}
+
+func TestObserver(t *testing.T) {
+	tests := []struct {
+		name     string
+		statuses []int
+		wantErr  bool
+		want     map[attribute.Key]attribute.Value
+		absent   []attribute.Key
+	}{
+		{
+			name: "success",
+			want: map[attribute.Key]attribute.Value{
+				AttrOperation:  attribute.StringValue("EventGet"),
+				AttrMethod:     attribute.StringValue("GET"),
+				AttrRoute:      attribute.StringValue("/events/{id}"),
+				AttrStatusCode: attribute.IntValue(200),
+			},
+			absent: []attribute.Key{AttrError, AttrRetries},
+		},
+		{
+			name:     "retried",
+			statuses: []int{http.StatusServiceUnavailable},
+			want: map[attribute.Key]attribute.Value{
+				AttrStatusCode: attribute.IntValue(200),
+				AttrRetries:    attribute.IntValue(1),
+			},
+			absent: []attribute.Key{AttrError},
+		},
+		{
+			name:     "api error",
+			statuses: []int{http.StatusNotFound},
+			wantErr:  true,
+			want: map[attribute.Key]attribute.Value{
+				AttrStatusCode: attribute.IntValue(404),
+				AttrError:      attribute.StringValue("NOT_FOUND"),
+			},
+			absent: []attribute.Key{AttrRetries},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			exporter := tracetest.NewInMemoryExporter()
+			tp := sdktrace.NewTracerProvider(sdktrace.WithSyncer(exporter))
+			reader := sdkmetric.NewManualReader()
+			mp := sdkmetric.NewMeterProvider(sdkmetric.WithReader(reader))
+
+			obs, err := New(tp, mp)
+			if err != nil {
+				t.Fatal(err)
+			}
+			srv := newServer(t, tt.statuses...)
+			clnt, err := eventbrite.NewClient(
+				eventbrite.WithToken("token"),
+				eventbrite.WithBaseURL(srv.URL),
+				eventbrite.WithRateLimit(0),
+				eventbrite.WithRetryPolicy(eventbrite.RetryPolicy{MaxAttempts: 3, MinBackoff: time.Millisecond}),
+				eventbrite.WithCallObserver(obs),
+			)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			_, err = clnt.EventGet(context.Background(), "42")
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("EventGet() error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			spans := exporter.GetSpans()
+			if len(spans) != 1 {
+				t.Fatalf("got %d spans, want 1", len(spans))
+			}
+			span := spans[0]
+			if span.Name != "EventGet" {
+				t.Errorf("span name = %q, want EventGet", span.Name)
+			}
+			if tt.wantErr != (span.Status.Code == codes.Error) {
+				t.Errorf("span status = %v, wantErr %v", span.Status.Code, tt.wantErr)
+			}
+
+			got := map[attribute.Key]attribute.Value{}
+			for _, kv := range span.Attributes {
+				got[kv.Key] = kv.Value
+			}
+			for k, v := range tt.want {
+				if got[k] != v {
+					t.Errorf("span attribute %s = %v, want %v", k, got[k].Emit(), v.Emit())
+				}
+			}
+			for _, k := range tt.absent {
+				if _, ok := got[k]; ok {
+					t.Errorf("span has attribute %s, want none", k)
+				}
+			}
+			if _, ok := got[AttrLimiterWait]; !ok {
+				t.Errorf("span has no %s attribute", AttrLimiterWait)
+			}
+
+			var rm metricdata.ResourceMetrics
+			if err := reader.Collect(context.Background(), &rm); err != nil {
+				t.Fatal(err)
+			}
+			metrics := map[string]metricdata.Aggregation{}
+			for _, sm := range rm.ScopeMetrics {
+				for _, m := range sm.Metrics {
+					metrics[m.Name] = m.Data
+				}
+			}
+
+			requests, ok := metrics[MetricRequests].(metricdata.Sum[int64])
+			if !ok || len(requests.DataPoints) != 1 || requests.DataPoints[0].Value != 1 {
+				t.Fatalf("%s = %+v, want a single call", MetricRequests, metrics[MetricRequests])
+			}
+			attrs := requests.DataPoints[0].Attributes
+			if v, _ := attrs.Value(AttrOperation); v.AsString() != "EventGet" {
+				t.Errorf("%s operation = %q, want EventGet", MetricRequests, v.AsString())
+			}
+			if v, _ := attrs.Value(AttrStatusCode); v != tt.want[AttrStatusCode] {
+				t.Errorf("%s status = %v, want %v", MetricRequests, v.Emit(), tt.want[AttrStatusCode].Emit())
+			}
+
+			for _, name := range []string{MetricDuration, MetricLimiterWait} {
+				h, ok := metrics[name].(metricdata.Histogram[float64])
+				if !ok || len(h.DataPoints) != 1 || h.DataPoints[0].Count != 1 {
+					t.Errorf("%s = %+v, want a single call", name, metrics[name])
+				}
+			}
+		})
+	}
+}
+
+func TestErrorType(t *testing.T) {
+	tests := []struct {
+		name   string
+		err    error
+		status int
+		want   string
+	}{
+		{"api error", &eventbrite.Error{Err: "NOT_AUTHORIZED", Status: 403}, 403, "NOT_AUTHORIZED"},
+		{"api error without key", &eventbrite.Error{Status: 502}, 502, "502"},
+		{"wrapped api error", &eventbrite.RetryError{Attempts: 2, Err: &eventbrite.Error{Err: "INTERNAL_ERROR"}}, 500, "INTERNAL_ERROR"},
+		{"canceled", context.Canceled, 0, "canceled"},
+		{"deadline", context.DeadlineExceeded, 0, "timeout"},
+		{"limiter closed", eventbrite.ErrLimiterClosed, 0, "limiter_closed"},
+		{"other", fmt.Errorf("boom"), 0, "_OTHER"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := errorType(tt.err, tt.status); got != tt.want {
+				t.Errorf("errorType() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -68,7 +68,7 @@ type GetTrackingBeaconForUserRequest struct {
 func (c *Client) TrackingBeaconCreate(ctx context.Context, req *CreateTrackingBeaconRequest) (*TrackingBeacon, error) {
 	res := new(TrackingBeacon)
 
-	return res, c.postJSON(ctx, "/tracking_beacons/", req, res)
+	return res, c.postJSON(ctx, "TrackingBeaconCreate", "/tracking_beacons/", req, res)
 }
 
 // TrackingBeaconGet returns the tracking_beacon with the specified :tracking_beacons_id
@@ -77,7 +77,7 @@ func (c *Client) TrackingBeaconCreate(ctx context.Context, req *CreateTrackingBe
 func (c *Client) TrackingBeaconGet(ctx context.Context, id string, req *GetTrackingBeaconRequest) (*TrackingBeacon, error) {
 	res := new(TrackingBeacon)
 
-	return res, c.getJSON(ctx, "/tracking_beacons/"+id, req, res)
+	return res, c.getJSON(ctx, "TrackingBeaconGet", "/tracking_beacons/"+id, req, res)
 }
 
 // TrackingBeaconGet updates the tracking_beacons with the specified :tracking_beacons_id. Though event_id and
@@ -88,7 +88,7 @@ func (c *Client) TrackingBeaconGet(ctx context.Context, id string, req *GetTrack
 func (c *Client) TrackingBeaconUpdate(ctx context.Context, id string, req *UpdateTrackingBeaconRequest) (*TrackingBeacon, error) {
 	res := new(TrackingBeacon)
 
-	return res, c.postJSON(ctx, "/tracking_beacons/"+id, req, res)
+	return res, c.postJSON(ctx, "TrackingBeaconUpdate", "/tracking_beacons/"+id, req, res)
 }
 
 // TrackingBeaconDelete delete the tracking_beacons with the specified :tracking_beacons_id
@@ -97,7 +97,7 @@ func (c *Client) TrackingBeaconUpdate(ctx context.Context, id string, req *Updat
 func (c *Client) TrackingBeaconDelete(ctx context.Context, id string) (*TrackingBeacon, error) {
 	res := new(TrackingBeacon)
 
-	return res, c.deleteJSON(ctx, "/tracking_beacons/"+id, res)
+	return res, c.deleteJSON(ctx, "TrackingBeaconDelete", "/tracking_beacons/"+id, res)
 }
 
 // TrackingBeaconGetForEvent returns the list of tracking_beacon for the event :event_id
@@ -106,7 +106,7 @@ func (c *Client) TrackingBeaconDelete(ctx context.Context, id string) (*Tracking
 func (c *Client) TrackingBeaconGetForEvent(ctx context.Context, eventId string, req *GetTrackingBeaconForEventRequest) (*TrackingBeacon, error) {
 	res := new(TrackingBeacon)
 
-	return res, c.getJSON(ctx, fmt.Sprintf("/events/%s/tracking_beacons/", eventId), req, res)
+	return res, c.getJSON(ctx, "TrackingBeaconGetForEvent", fmt.Sprintf("/events/%s/tracking_beacons/", eventId), req, res)
 }
 
 // TrackingBeaconGetForUser returns the list of tracking_beacon for the user :user_id
@@ -115,5 +115,5 @@ func (c *Client) TrackingBeaconGetForEvent(ctx context.Context, eventId string,
 func (c *Client) TrackingBeaconGetForUser(ctx context.Context, userId string, req *GetTrackingBeaconForUserRequest) (*TrackingBeacon, error) {
 	res := new(TrackingBeacon)
 
-	return res, c.getJSON(ctx, fmt.Sprintf("/events/%s/tracking_beacons/", userId), req, res)
+	return res, c.getJSON(ctx, "TrackingBeaconGetForUser", fmt.Sprintf("/events/%s/tracking_beacons/", userId), req, res)
 }
@@ -6,29 +6,6 @@ import (
 	"time"
 )
 
-// When an error occurs during an API request, you’ll get a response with an error HTTP status
-// (in the 400 or 500 range), as well as a JSON response containing more information about the error.
-//
-// https://www.eventbrite.co.uk/developer/v3/api_overview/errors/#ebapi-errors
-type Error struct {
-	// The error key contains a constant string value for error - in this case, VENUE_AND_ONLINE - and
-	// is what you should key your error handling off of, as this string won’t change depending on locale
-	// or as we change the API over time
-	Err string `json:"error"`
-	// The error_description key is for developer information only and will usually contain a more informative
-	// explanation for the error, should you be confused. You should not display this string to your users;
-	// it’s often very technical and may not be localized to their language
-	Description string `json:"error_description"`
-	// The status_code value just mirrors the HTTP status code you got as part of the request. It’s there as
-	// a convenience if your HTTP library makes it very hard to get status codes, or has one error handler
-	// for all error codes
-	Status int `json:"status_code"`
-}
-
-func (e Error) Error() string {
-	return fmt.Sprintf("Eventbrite API: [Status code - %d] %s", e.Status, e.Description)
-}
-
 // The ISO 3166 alpha-2 code of a country.
 type CountryCode string
 
@@ -46,6 +23,9 @@ type Date struct {
 }
 
 func (d *Date) UnmarshalJSON(data []byte) error {
+	if string(data) == "null" {
+		return nil
+	}
 	data = bytes.Replace(data, []byte("\""), []byte(""), -1)
 	t, err := time.Parse("2006-01-02", string(data))
 	if err != nil {
@@ -65,6 +45,9 @@ type DateTime struct {
 }
 
 func (d *DateTime) UnmarshalJSON(data []byte) error {
+	if string(data) == "null" {
+		return nil
+	}
 	data = bytes.Replace(data, []byte("\""), []byte(""), -1)
 	t, err := time.Parse("2006-01-02T15:04:05Z", string(data))
 	if err != nil {
@@ -76,7 +59,7 @@ func (d *DateTime) UnmarshalJSON(data []byte) error {
 }
 
 func (d DateTime) MarshalJSON() ([]byte, error) {
-	return []byte("\"" + d.Time.Format("2006-01-02T15:04:05Z") + "\""), nil
+	return []byte("\"" + d.Time.UTC().Format("2006-01-02T15:04:05Z") + "\""), nil
 }
 
 // Timezone is an object with details about a timezone
@@ -100,6 +83,8 @@ type Pagination struct {
 	PageSize     int  `json:"page_size"`
 	PageCount    int  `json:"page_count"`
 	HasMoreItems bool `json:"has_more_items"`
+	// The token to pass as the continuation parameter to fetch the next page
+	Continuation string `json:"continuation"`
 }
 
 // Returned for fields which represent HTML, like event names and descriptions.
@@ -159,6 +144,8 @@ type Image struct {
 //
 // https://www.eventbrite.com/developer/v3/response_formats/venue/#ebapi-venue
 type Venue struct {
+	// The venue ID
+	ID string `json:"id"`
 	// The value name
 	Name string `json:"name"`
 	// The address of the venue
@@ -199,6 +186,8 @@ type Address struct {
 //
 // https://www.eventbrite.com/developer/v3/response_formats/organizer/#ebapi-std:format-organizer
 type Organizer struct {
+	// The organizer ID
+	ID string `json:"id"`
 	// The organizer name
 	Name string `json:"name"`
 	// The description of the organizer (may be very long and contain significant formatting)
@@ -334,19 +323,27 @@ type TrackingBeacon struct {
 
 // An object representing a single webhook associated with the account
 type Webhook struct {
+	// The webhook ID
+	ID string `json:"id"`
 	// The url that the webhook will send data to when it is triggered
 	EndpointUrl string `json:"endpoint_url"`
 	// One or any combination of actions that will cause this webhook to fire
 	Actions string `json:"actions"`
+	// The ID of the event that triggers this webhook, empty for all events
+	EventID string `json:"event_id"`
 }
 
 // Attendee is an object representing the details of one or more people coming to the event
 // Attendee objects are considered private and are only available to the event owner
 type Attendee struct {
+	// The attendee ID
+	ID string `json:"id"`
 	// When the attendee was created (order placed)
 	Created DateTime `json:"created"`
 	// When the attendee was last changed
 	Changed DateTime `json:"changed"`
+	// The ID of the ticket_class the attendee registered with
+	TicketClassID string `json:"ticket_class_id"`
 	// The name of the ticket_class at the time of registration
 	TicketClassName string `json:"ticket_class_name"`
 	// The attendee’s basic profile information
@@ -354,9 +351,9 @@ type Attendee struct {
 	// The attendee’s basic profile information
 	Addresses AttendeeAddresses `json:"addresses"`
 	// The attendee’s answers to any custom questions (optional)
-	Answers AttendeeAnswers `json:"answers"`
+	Answers []AttendeeAnswers `json:"answers"`
 	// The attendee’s entry barcode information
-	Barcodes AttendeeBarcodes `json:"barcodes"`
+	Barcodes []AttendeeBarcodes `json:"barcodes"`
 	// The attendee’s team information (optional)
 	Team AttendeeTeam `json:"team"`
 	// The attendee’s affiliate code (optional)
@@ -393,6 +390,26 @@ type Attendee struct {
 	AssignedNumber interface{} `json:"assigned_number"`
 }
 
+// Transfer is the move of the tickets of an order to another ticket class or event
+//
+// https://www.eventbrite.com/developer/v3/endpoints/events/#ebapi-get-events-id-transfers
+type Transfer struct {
+	// The transfer ID
+	ID string `json:"id"`
+	// When the transfer was created
+	Created DateTime `json:"created"`
+	// When the transfer was last changed
+	Changed DateTime `json:"changed"`
+	// The event id the tickets were transferred from
+	EventID string `json:"event_id"`
+	// The order id whose tickets were transferred
+	OrderID string `json:"order_id"`
+	// The transferred attendees and their new ticket classes
+	//
+	// Not documented
+	Details interface{} `json:"details"`
+}
+
 // Contains the attendee’s personal information
 //
 // https://www.eventbrite.com/developer/v3/response_formats/attendee/#ebapi-std:format-attendee-profile
@@ -106,7 +106,7 @@ type GetUserEvents struct {
 	// Filter event results by event_group_id
 	EventGroupID string `json:"event_group_id"`
 	// Number of records in each page
-	PageSize int `json:"page_size"`
+	PageSize int `json:"page_size" url:",omitempty"`
 	// Limits results to either past or current & future events / orders. (Valid choices are: all, past, or current_future
 	TimeFilter string `json:"time_filter"`
 	// Filter event results by venue IDs
@@ -204,6 +204,8 @@ type CreateOrganizationVenueRequest struct {
 //
 // https://www.eventbrite.com/developer/v3/endpoints/users/#ebapi-id15
 type UserEventAttendeesRequest struct {
+	PageRequest
+
 	// Limits results to either confirmed attendees or cancelled/refunded/etc. attendees
 	// (Valid choices are: attending, or not_attending)
 	Status string `json:"status"`
@@ -216,7 +218,7 @@ type UserEventAttendeesRequest struct {
 // https://www.eventbrite.co.uk/developer/v3/endpoints/users/#ebapi-get-users-id-owned-event-attendees
 type UserEventAttendeesResponse struct {
 	Pagination Pagination `json:"pagination"`
-	Attendees  []Attendee `json:"attendee"`
+	Attendees  []Attendee `json:"attendees"`
 }
 
 // UserEventOrders is the request structure to get all order placed under
@@ -224,6 +226,8 @@ type UserEventAttendeesResponse struct {
 //
 // https://www.eventbrite.com/developer/v3/endpoints/users/#ebapi-id17
 type UserEventOrders struct {
+	PageRequest
+
 	// Limits results to either past or current & future events / orders.
 	// (Valid choices are: all, past, or current_future)
 	TimeFilter string `json:"time_filter"`
@@ -243,6 +247,8 @@ type UserOrdersResult struct {
 //
 // https://www.eventbrite.co.uk/developer/v3/endpoints/users/#ebapi-id3
 type UserOrganizerRequest struct {
+	PageRequest
+
 	// True: Will hide organizers flagged as “unsaved” False: Will show organizers
 	// regardless of unsaved flag (Default value)
 	HideUnsaved bool `json:"hide_unsaved"`
@@ -258,6 +264,8 @@ type UserOrganizerResponse struct {
 //
 // https://www.eventbrite.co.uk/developer/v3/endpoints/users/#ebapi-id5
 type UserOwnedEventsRequest struct {
+	PageRequest
+
 	// How to order the results (Valid choices are: start_asc, start_desc, created_asc,
 	// created_desc, name_asc, or name_desc)
 	OrderBy string `json:"order_by"`
@@ -274,10 +282,12 @@ type UserOwnedEventResponse struct {
 	Events     []Event    `json:"events"`
 }
 
+// UserEventsRequest is the request structure to get events the user has access to
+//
+// https://www.eventbrite.co.uk/developer/v3/endpoints/users/#ebapi-id7
 type UserEventsRequest struct {
-}
+	PageRequest
 
-type UserEventsResponse struct {
 	// Filter event results by name
 	NameFilter string `json:"name_filter"`
 	// Filter event results by currency
@@ -292,13 +302,24 @@ type UserEventsResponse struct {
 	// Filter event results by event_group_id
 	EventGroupID string `json:"event_group_id"`
 	// Number of records in each page.
-	PageSize int `json:"page_size"`
+	PageSize int `json:"page_size" url:",omitempty"`
 	// Limits results to either past or current & future events / orders. (Valid choices are: all, past, or current_future)
 	TimeFilter string `json:"time_filter"`
 	// Filter event results by venue IDs
 	VenueFilter []interface{} `json:"venue_filter"`
 }
 
+// UserEventsResponse is the response structure to get events the user has access to
+type UserEventsResponse struct {
+	Pagination Pagination `json:"pagination"`
+	Events     []Event    `json:"events"`
+}
+
+// UserVenuesRequest is the request structure to get user owned venues
+type UserVenuesRequest struct {
+	PageRequest
+}
+
 // UserVenuesResponse is the response structure to get user owned venues
 type UserVenuesResponse struct {
 	Pagination Pagination `json:"pagination"`
@@ -307,10 +328,13 @@ type UserVenuesResponse struct {
 
 // https://www.eventbrite.com/developer/v3/endpoints/users/#ebapi-id17
 type UserEventOrdersRequest struct {
+	PageRequest
+
 	Status        string   `json:"status"`
 	OnlyEmails    []string `json:"only_emails"`
 	ExcludeEmails []string `json:"exclude_emails"`
-	ChangedSince  DateTime `json:"datetime"`
+	// Only return resource changed on or after the time given
+	ChangedSince string `json:"changed_since"`
 }
 
 // https://www.eventbrite.com/developer/v3/endpoints/users/#ebapi-get-users-id-owned-event-orders
@@ -319,6 +343,11 @@ type UserEventOrdersResponse struct {
 	Orders     []Order    `json:"orders"`
 }
 
+// UserContactListsRequest is the request structure to get user contact lists
+type UserContactListsRequest struct {
+	PageRequest
+}
+
 // UserContactListsResponse is the response structure to get user contact lists
 type UserContactListsResponse struct {
 	Pagination  Pagination    `json:"pagination"`
@@ -347,6 +376,11 @@ type UserDeleteContactListContactRequest struct {
 	Email string `json:"email"`
 }
 
+// UserContactListContactsRequest is the request structure to get the contacts of a contact list
+type UserContactListContactsRequest struct {
+	PageRequest
+}
+
 type UserContactListContacts struct {
 	Pagination Pagination `json:"pagination"`
 	Contacts   []Contact  `json:"contacts"`
@@ -354,6 +388,8 @@ type UserContactListContacts struct {
 
 // https://www.eventbrite.com/developer/v3/endpoints/users/#ebapi-id35
 type UserBookmarksRequest struct {
+	PageRequest
+
 	// Optional bookmark list id to fetch all bookmarks from
 	BookmarkListID string `json:"bookmark_list_id"`
 }
@@ -403,7 +439,7 @@ type UserSetAssortmentRequest struct {
 func (c *Client) User(ctx context.Context, id string) (*User, error) {
 	u := new(User)
 
-	return u, c.getJSON(ctx, fmt.Sprintf("/users/%s/", id), nil, u)
+	return u, c.getJSON(ctx, "User", fmt.Sprintf("/users/%s/", id), nil, u)
 }
 
 // UserOrders returns a paginated response of orders, under the key orders, of all orders
@@ -413,7 +449,18 @@ func (c *Client) User(ctx context.Context, id string) (*User, error) {
 func (c *Client) UserOrders(ctx context.Context, id string, req *UserEventOrders) (*UserOrdersResult, error) {
 	r := new(UserOrdersResult)
 
-	return r, c.getJSON(ctx, fmt.Sprintf("/users/%s/orders/", id), req, r)
+	return r, c.getJSON(ctx, "UserOrders", fmt.Sprintf("/users/%s/orders/", id), req, r)
+}
+
+// UserOrdersIterator returns an Iterator over every order placed by the user
+func (c *Client) UserOrdersIterator(ctx context.Context, id string, req *UserEventOrders) *Iterator[Order] {
+	return iterate(ctx, req, func(ctx context.Context, r *UserEventOrders) ([]Order, Pagination, error) {
+		res, err := c.UserOrders(ctx, id, r)
+		if err != nil {
+			return nil, Pagination{}, err
+		}
+		return res.Orders, res.Pagination, nil
+	})
 }
 
 // UserOrganizers returns a paginated response of organizer objects that are owned by the user.
@@ -422,32 +469,78 @@ func (c *Client) UserOrders(ctx context.Context, id string, req *UserEventOrders
 func (c *Client) UserOrganizers(ctx context.Context, id string, req *UserOrganizerRequest) (*UserOrganizerResponse, error) {
 	r := new(UserOrganizerResponse)
 
-	return r, c.getJSON(ctx, fmt.Sprintf("/users/%s/organizers/", id), req, r)
+	return r, c.getJSON(ctx, "UserOrganizers", fmt.Sprintf("/users/%s/organizers/", id), req, r)
 }
 
-// UserOrganizers returns a paginated response of organizer objects that are owned by the user.
+// UserOrganizersIterator returns an Iterator over every organizer owned by the user
+func (c *Client) UserOrganizersIterator(ctx context.Context, id string, req *UserOrganizerRequest) *Iterator[Organizer] {
+	return iterate(ctx, req, func(ctx context.Context, r *UserOrganizerRequest) ([]Organizer, Pagination, error) {
+		res, err := c.UserOrganizers(ctx, id, r)
+		if err != nil {
+			return nil, Pagination{}, err
+		}
+		return res.Organizers, res.Pagination, nil
+	})
+}
+
+// UserOwnedEvents returns a paginated response of events, under the key events, of all events the user owns
 //
-// https://www.eventbrite.co.uk/developer/v3/endpoints/users/#ebapi-get-users-id-organizers
+// https://www.eventbrite.co.uk/developer/v3/endpoints/users/#ebapi-get-users-id-owned-events
 func (c *Client) UserOwnedEvents(ctx context.Context, id string, req *UserOwnedEventsRequest) (*UserOwnedEventResponse, error) {
 	r := new(UserOwnedEventResponse)
 
-	return r, c.getJSON(ctx, fmt.Sprintf("/users/%s/owned_events/", id), req, r)
+	return r, c.getJSON(ctx, "UserOwnedEvents", fmt.Sprintf("/users/%s/owned_events/", id), req, r)
+}
+
+// UserOwnedEventsIterator returns an Iterator over every event owned by the user
+func (c *Client) UserOwnedEventsIterator(ctx context.Context, id string, req *UserOwnedEventsRequest) *Iterator[Event] {
+	return iterate(ctx, req, func(ctx context.Context, r *UserOwnedEventsRequest) ([]Event, Pagination, error) {
+		res, err := c.UserOwnedEvents(ctx, id, r)
+		if err != nil {
+			return nil, Pagination{}, err
+		}
+		return res.Events, res.Pagination, nil
+	})
 }
 
 // UserEvents returns a paginated response of events, under the key events, of all events the user has access to
 //
 // https://www.eventbrite.co.uk/developer/v3/endpoints/users/#ebapi-get-users-id-events
-func (c *Client) UserEvents(ctx context.Context, id string, req UserEventsRequest) (*UserEventsResponse, error) {
+func (c *Client) UserEvents(ctx context.Context, id string, req *UserEventsRequest) (*UserEventsResponse, error) {
 	r := new(UserEventsResponse)
 
-	return r, c.getJSON(ctx, fmt.Sprintf("/users/%s/events/", id), req, r)
+	return r, c.getJSON(ctx, "UserEvents", fmt.Sprintf("/users/%s/events/", id), req, r)
+}
+
+// UserEventsIterator returns an Iterator over every event the user has access to
+func (c *Client) UserEventsIterator(ctx context.Context, id string, req *UserEventsRequest) *Iterator[Event] {
+	return iterate(ctx, req, func(ctx context.Context, r *UserEventsRequest) ([]Event, Pagination, error) {
+		res, err := c.UserEvents(ctx, id, r)
+		if err != nil {
+			return nil, Pagination{}, err
+		}
+		return res.Events, res.Pagination, nil
+	})
 }
 
 // UserVenues returns a paginated response of venue objects that are owned by the user
-func (c *Client) UserVenues(ctx context.Context, id string) (*UserVenuesResponse, error) {
+//
+// https://www.eventbrite.co.uk/developer/v3/endpoints/users/#ebapi-get-users-id-venues
+func (c *Client) UserVenues(ctx context.Context, id string, req *UserVenuesRequest) (*UserVenuesResponse, error) {
 	r := new(UserVenuesResponse)
 
-	return r, c.getJSON(ctx, fmt.Sprintf("/users/%s/events/", id), nil, r)
+	return r, c.getJSON(ctx, "UserVenues", fmt.Sprintf("/users/%s/venues/", id), req, r)
+}
+
+// UserVenuesIterator returns an Iterator over every venue owned by the user
+func (c *Client) UserVenuesIterator(ctx context.Context, id string, req *UserVenuesRequest) *Iterator[Venue] {
+	return iterate(ctx, req, func(ctx context.Context, r *UserVenuesRequest) ([]Venue, Pagination, error) {
+		res, err := c.UserVenues(ctx, id, r)
+		if err != nil {
+			return nil, Pagination{}, err
+		}
+		return res.Venues, res.Pagination, nil
+	})
 }
 
 // UserEventAttendees returns a paginated response of attendees, under the key attendees, of attendees visiting
@@ -457,8 +550,18 @@ func (c *Client) UserVenues(ctx context.Context, id string) (*UserVenuesResponse
 func (c *Client) UserEventAttendees(ctx context.Context, id string, request *UserEventAttendeesRequest) (*UserEventAttendeesResponse, error) {
 	r := new(UserEventAttendeesResponse)
 
-	return r, c.getJSON(ctx, fmt.Sprintf("/users/%s/owned_event_attendees/", id), request, r)
+	return r, c.getJSON(ctx, "UserEventAttendees", fmt.Sprintf("/users/%s/owned_event_attendees/", id), request, r)
+}
 
+// UserEventAttendeesIterator returns an Iterator over every attendee of the events the user owns
+func (c *Client) UserEventAttendeesIterator(ctx context.Context, id string, req *UserEventAttendeesRequest) *Iterator[Attendee] {
+	return iterate(ctx, req, func(ctx context.Context, r *UserEventAttendeesRequest) ([]Attendee, Pagination, error) {
+		res, err := c.UserEventAttendees(ctx, id, r)
+		if err != nil {
+			return nil, Pagination{}, err
+		}
+		return res.Attendees, res.Pagination, nil
+	})
 }
 
 // UserEventOrders returns a paginated response of orders, under the key orders, of orders placed against any of
@@ -468,17 +571,38 @@ func (c *Client) UserEventAttendees(ctx context.Context, id string, request *Use
 func (c *Client) UserEventOrders(ctx context.Context, id string, request *UserEventOrdersRequest) (*UserEventOrdersResponse, error) {
 	r := new(UserEventOrdersResponse)
 
-	return r, c.getJSON(ctx, fmt.Sprintf("/users/%s/owned_event_orders/", id), request, r)
+	return r, c.getJSON(ctx, "UserEventOrders", fmt.Sprintf("/users/%s/owned_event_orders/", id), request, r)
+}
 
+// UserEventOrdersIterator returns an Iterator over every order placed against the events the user owns
+func (c *Client) UserEventOrdersIterator(ctx context.Context, id string, req *UserEventOrdersRequest) *Iterator[Order] {
+	return iterate(ctx, req, func(ctx context.Context, r *UserEventOrdersRequest) ([]Order, Pagination, error) {
+		res, err := c.UserEventOrders(ctx, id, r)
+		if err != nil {
+			return nil, Pagination{}, err
+		}
+		return res.Orders, res.Pagination, nil
+	})
 }
 
 // UserContactLists returns a list of contact_list that the user owns as the key contact_lists
 //
 // https://www.eventbrite.com/developer/v3/endpoints/users/#ebapi-get-users-id-contact-lists
-func (c *Client) UserContactLists(ctx context.Context, id string) (*UserContactListsResponse, error) {
+func (c *Client) UserContactLists(ctx context.Context, id string, req *UserContactListsRequest) (*UserContactListsResponse, error) {
 	r := new(UserContactListsResponse)
 
-	return r, c.getJSON(ctx, fmt.Sprintf("/users/%s/contact_lists/", id), nil, r)
+	return r, c.getJSON(ctx, "UserContactLists", fmt.Sprintf("/users/%s/contact_lists/", id), req, r)
+}
+
+// UserContactListsIterator returns an Iterator over every contact list the user owns
+func (c *Client) UserContactListsIterator(ctx context.Context, id string, req *UserContactListsRequest) *Iterator[ContactList] {
+	return iterate(ctx, req, func(ctx context.Context, r *UserContactListsRequest) ([]ContactList, Pagination, error) {
+		res, err := c.UserContactLists(ctx, id, r)
+		if err != nil {
+			return nil, Pagination{}, err
+		}
+		return res.ContactList, res.Pagination, nil
+	})
 }
 
 // UserCreateContactList makes a new contact_list for the user and returns it as contact_list
@@ -487,7 +611,7 @@ func (c *Client) UserContactLists(ctx context.Context, id string) (*UserContactL
 func (c *Client) UserCreateContactList(ctx context.Context, id string, request *UserCreateContactListsRequest) (*UserContactListsResponse, error) {
 	r := new(UserContactListsResponse)
 
-	return r, c.postJSON(ctx, fmt.Sprintf("/users/%s/owned_event_orders/", id), request, r)
+	return r, c.postJSON(ctx, "UserCreateContactList", fmt.Sprintf("/users/%s/owned_event_orders/", id), request, r)
 }
 
 // UserContactList gets a user’s contact_list by ID as contact_list
@@ -496,7 +620,7 @@ func (c *Client) UserCreateContactList(ctx context.Context, id string, request *
 func (c *Client) UserContactList(ctx context.Context, id, contactListID string, request *UserCreateContactListsRequest) (*UserContactListsResponse, error) {
 	r := new(UserContactListsResponse)
 
-	return r, c.getJSON(ctx, fmt.Sprintf("/users/%s/contact_lists/%s/", id, contactListID), request, r)
+	return r, c.getJSON(ctx, "UserContactList", fmt.Sprintf("/users/%s/contact_lists/%s/", id, contactListID), request, r)
 }
 
 // UserUpdateContactList updates the contact_list and returns it as contact_list
@@ -505,7 +629,7 @@ func (c *Client) UserContactList(ctx context.Context, id, contactListID string,
 func (c *Client) UserUpdateContactList(ctx context.Context, id, contactListID string, request *UserUpdateContactListRequest) (*UserContactListsResponse, error) {
 	r := new(UserContactListsResponse)
 
-	return r, c.postJSON(ctx, fmt.Sprintf("/users/%s/contact_lists/%s/", id, contactListID), request, r)
+	return r, c.postJSON(ctx, "UserUpdateContactList", fmt.Sprintf("/users/%s/contact_lists/%s/", id, contactListID), request, r)
 }
 
 // UserDeleteContactList deletes the contact list. Returns {"deleted": true}
@@ -514,16 +638,27 @@ func (c *Client) UserUpdateContactList(ctx context.Context, id, contactListID st
 func (c *Client) UserDeleteContactList(ctx context.Context, id, contactListID string) (interface{}, error) {
 	var r interface{}
 
-	return r, c.deleteJSON(ctx, fmt.Sprintf("/users/%s/contact_lists/%s/", id, contactListID), r)
+	return r, c.deleteJSON(ctx, "UserDeleteContactList", fmt.Sprintf("/users/%s/contact_lists/%s/", id, contactListID), r)
 }
 
 // UserContactListContacts returns the contacts on the contact list as contacts
 //
 // https://www.eventbrite.com/developer/v3/endpoints/users/#ebapi-get-users-id-contact-lists-contact-list-id-contacts
-func (c *Client) UserListContactContacts(ctx context.Context, id, contactListID string) (*UserContactListContacts, error) {
+func (c *Client) UserListContactContacts(ctx context.Context, id, contactListID string, req *UserContactListContactsRequest) (*UserContactListContacts, error) {
 	r := new(UserContactListContacts)
 
-	return r, c.postJSON(ctx, fmt.Sprintf("/users/%s/contact_lists/%s/contacts/", id, contactListID), nil, r)
+	return r, c.getJSON(ctx, "UserListContactContacts", fmt.Sprintf("/users/%s/contact_lists/%s/contacts/", id, contactListID), req, r)
+}
+
+// UserListContactContactsIterator returns an Iterator over every contact of the contact list
+func (c *Client) UserListContactContactsIterator(ctx context.Context, id, contactListID string, req *UserContactListContactsRequest) *Iterator[Contact] {
+	return iterate(ctx, req, func(ctx context.Context, r *UserContactListContactsRequest) ([]Contact, Pagination, error) {
+		res, err := c.UserListContactContacts(ctx, id, contactListID, r)
+		if err != nil {
+			return nil, Pagination{}, err
+		}
+		return res.Contacts, res.Pagination, nil
+	})
 }
 
 // UserContactListContacts adds a new contact to the contact list. Returns {"created": true}
@@ -533,7 +668,7 @@ func (c *Client) UserListContactContacts(ctx context.Context, id, contactListID
 func (c *Client) UserListContactAddContacts(ctx context.Context, id, contactListID string, req *UserAddContactListContactRequest) (*UserContactListContacts, error) {
 	r := new(UserContactListContacts)
 
-	return r, c.postJSON(ctx, fmt.Sprintf("/users/%s/contact_lists/%s/contacts/", id, contactListID), req, r)
+	return r, c.postJSON(ctx, "UserListContactAddContacts", fmt.Sprintf("/users/%s/contact_lists/%s/contacts/", id, contactListID), req, r)
 }
 
 // UserContactListContacts adds a new contact to the contact list. Returns {"created": true}
@@ -543,7 +678,7 @@ func (c *Client) UserListContactAddContacts(ctx context.Context, id, contactList
 func (c *Client) UserListContactDeleteContacts(ctx context.Context, id, contactListID string) (interface{}, error) {
 	r := new(UserContactListContacts)
 
-	return r, c.deleteJSON(ctx, fmt.Sprintf("/users/%s/contact_lists/%s/contacts/", id, contactListID), r)
+	return r, c.deleteJSON(ctx, "UserListContactDeleteContacts", fmt.Sprintf("/users/%s/contact_lists/%s/contacts/", id, contactListID), r)
 }
 
 // UserBookmarks gets all the user’s saved events.
@@ -554,7 +689,18 @@ func (c *Client) UserListContactDeleteContacts(ctx context.Context, id, contactL
 func (c *Client) UserBookmarks(ctx context.Context, id string, req *UserBookmarksRequest) (*UserBookmarksResponse, error) {
 	r := new(UserBookmarksResponse)
 
-	return r, c.getJSON(ctx, fmt.Sprintf("/users/%s/bookmarks/", id), req, r)
+	return r, c.getJSON(ctx, "UserBookmarks", fmt.Sprintf("/users/%s/bookmarks/", id), req, r)
+}
+
+// UserBookmarksIterator returns an Iterator over every event saved by the user
+func (c *Client) UserBookmarksIterator(ctx context.Context, id string, req *UserBookmarksRequest) *Iterator[Event] {
+	return iterate(ctx, req, func(ctx context.Context, r *UserBookmarksRequest) ([]Event, Pagination, error) {
+		res, err := c.UserBookmarks(ctx, id, r)
+		if err != nil {
+			return nil, Pagination{}, err
+		}
+		return res.Events, res.Pagination, nil
+	})
 }
 
 // UserSaveBookmarks adds a new bookmark for the user. Returns {"created": true}.
@@ -564,7 +710,7 @@ func (c *Client) UserBookmarks(ctx context.Context, id string, req *UserBookmark
 func (c *Client) UserSaveBookmarks(ctx context.Context, id string, req *UserSaveBookmarkRequest) (interface{}, error) {
 	var v interface{}
 
-	return v, c.getJSON(ctx, fmt.Sprintf("/users/%s/bookmarks/save", id), req, v)
+	return v, c.getJSON(ctx, "UserSaveBookmarks", fmt.Sprintf("/users/%s/bookmarks/save", id), req, v)
 }
 
 // UserUnSaveBookmarks removes the specified bookmark from the event for the user. Returns {"deleted": true}.
@@ -574,7 +720,7 @@ func (c *Client) UserSaveBookmarks(ctx context.Context, id string, req *UserSave
 func (c *Client) UserUnSaveBookmarks(ctx context.Context, id string, req *UserUnSaveBookmarkRequest) (interface{}, error) {
 	var v interface{}
 
-	return v, c.getJSON(ctx, fmt.Sprintf("/users/%s/bookmarks/unsave", id), req, v)
+	return v, c.getJSON(ctx, "UserUnSaveBookmarks", fmt.Sprintf("/users/%s/bookmarks/unsave", id), req, v)
 }
 
 // UserAssortments retrieve the assortment for the user
@@ -583,7 +729,7 @@ func (c *Client) UserUnSaveBookmarks(ctx context.Context, id string, req *UserUn
 func (c *Client) UserAssortments(ctx context.Context, id string) (*Assortment, error) {
 	a := new(Assortment)
 
-	return a, c.getJSON(ctx, fmt.Sprintf("/users/%s/assortment/", id), nil, a)
+	return a, c.getJSON(ctx, "UserAssortments", fmt.Sprintf("/users/%s/assortment/", id), nil, a)
 }
 
 // UserSetAssortments set a user’s assortment and returns the assortment for the specified user.
@@ -592,5 +738,5 @@ func (c *Client) UserAssortments(ctx context.Context, id string) (*Assortment, e
 func (c *Client) UserSetAssortments(ctx context.Context, id string, req *UserSetAssortmentRequest) (*Assortment, error) {
 	a := new(Assortment)
 
-	return a, c.postJSON(ctx, fmt.Sprintf("/users/%s/assortment/", id), req, a)
+	return a, c.postJSON(ctx, "UserSetAssortments", fmt.Sprintf("/users/%s/assortment/", id), req, a)
 }
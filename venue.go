@@ -69,6 +69,8 @@ type VenueEventsResult struct {
 
 // https://www.eventbrite.com/developer/v3/endpoints/venues/#ebapi-id5
 type GetVenueEventsRequest struct {
+	PageRequest
+
 	Status              string `json:"status"`
 	OrderBy             string `json:"order_by"`
 	StartDateRangeStart string `json:"start_date.range_start"`
@@ -82,7 +84,7 @@ type GetVenueEventsRequest struct {
 func (c *Client) VenueGet(ctx context.Context, id string) (*Venue, error) {
 	res := new(Venue)
 
-	return res, c.getJSON(ctx, fmt.Sprintf("/venues/%s/", id), nil, res)
+	return res, c.getJSON(ctx, "VenueGet", fmt.Sprintf("/venues/%s/", id), nil, res)
 }
 
 // Updates a venue and returns it as an object
@@ -91,7 +93,7 @@ func (c *Client) VenueGet(ctx context.Context, id string) (*Venue, error) {
 func (c *Client) VenueUpdate(ctx context.Context, id string, req *UpdateVenueRequest) (*Venue, error) {
 	res := new(Venue)
 
-	return res, c.getJSON(ctx, fmt.Sprintf("/venues/%s/", id), nil, res)
+	return res, c.postJSON(ctx, "VenueUpdate", fmt.Sprintf("/venues/%s/", id), req, res)
 }
 
 // Creates a new venue with associated address
@@ -100,14 +102,25 @@ func (c *Client) VenueUpdate(ctx context.Context, id string, req *UpdateVenueReq
 func (c *Client) VenueCreate(ctx context.Context, req *CreateVenueRequest) (*Venue, error) {
 	res := new(Venue)
 
-	return res, c.postJSON(ctx, "/venues/", nil, res)
+	return res, c.postJSON(ctx, "VenueCreate", "/venues/", req, res)
 }
 
-// Creates a new venue with associated address
+// Returns events of a given venue
 //
-// https://www.eventbrite.com/developer/v3/endpoints/venues/#ebapi-post-venues
-func (c *Client) VenueEvents(ctx context.Context, venueId string) (*VenueEventsResult, error) {
+// https://www.eventbrite.com/developer/v3/endpoints/venues/#ebapi-get-venues-id-events
+func (c *Client) VenueEvents(ctx context.Context, venueId string, req *GetVenueEventsRequest) (*VenueEventsResult, error) {
 	res := new(VenueEventsResult)
 
-	return res, c.postJSON(ctx, fmt.Sprintf("/venues/%s/events/", venueId), nil, res)
+	return res, c.getJSON(ctx, "VenueEvents", fmt.Sprintf("/venues/%s/events/", venueId), req, res)
+}
+
+// VenueEventsIterator returns an Iterator over every event of the venue
+func (c *Client) VenueEventsIterator(ctx context.Context, venueId string, req *GetVenueEventsRequest) *Iterator[Event] {
+	return iterate(ctx, req, func(ctx context.Context, r *GetVenueEventsRequest) ([]Event, Pagination, error) {
+		res, err := c.VenueEvents(ctx, venueId, r)
+		if err != nil {
+			return nil, Pagination{}, err
+		}
+		return res.Events, res.Pagination, nil
+	})
 }
@@ -8,6 +8,8 @@ import (
 
 // https://www.eventbrite.com/developer/v3/endpoints/webhooks/#ebapi-id3
 type WebhooksRequest struct {
+	PageRequest
+
 	// The organization for which the webhooks will be fetched
 	OrganizationID string `json:"organization_id"`
 }
@@ -37,7 +39,7 @@ type CreateWebhookRequest struct {
 func (c *Client) WebhookGet(ctx context.Context, id string) (*Webhook, error) {
 	res := new(Webhook)
 
-	return res, c.getJSON(ctx, fmt.Sprintf("/webhooks/%s/", id), nil, res)
+	return res, c.getJSON(ctx, "WebhookGet", fmt.Sprintf("/webhooks/%s/", id), nil, res)
 }
 
 // Deletes the specified webhook object
@@ -46,7 +48,7 @@ func (c *Client) WebhookGet(ctx context.Context, id string) (*Webhook, error) {
 func (c *Client) WebhookDelete(ctx context.Context, id string) (*Webhook, error) {
 	res := new(Webhook)
 
-	return res, c.deleteJSON(ctx, fmt.Sprintf("/webhooks/%s/", id), res)
+	return res, c.deleteJSON(ctx, "WebhookDelete", fmt.Sprintf("/webhooks/%s/", id), res)
 }
 
 // Returns the list of webhook objects that belong to the authenticated user
@@ -55,7 +57,18 @@ func (c *Client) WebhookDelete(ctx context.Context, id string) (*Webhook, error)
 func (c *Client) Webhooks(ctx context.Context, req *WebhooksRequest) (*WebhooksResult, error) {
 	res := new(WebhooksResult)
 
-	return res, c.getJSON(ctx, fmt.Sprintf("/webhooks/"), req, res)
+	return res, c.getJSON(ctx, "Webhooks", fmt.Sprintf("/webhooks/"), req, res)
+}
+
+// WebhooksIterator returns an Iterator over every webhook returned by Webhooks
+func (c *Client) WebhooksIterator(ctx context.Context, req *WebhooksRequest) *Iterator[Webhook] {
+	return iterate(ctx, req, func(ctx context.Context, r *WebhooksRequest) ([]Webhook, Pagination, error) {
+		res, err := c.Webhooks(ctx, r)
+		if err != nil {
+			return nil, Pagination{}, err
+		}
+		return res.Webhooks, res.Pagination, nil
+	})
 }
 
 // Creates a webhook for the authenticated user
@@ -64,5 +77,5 @@ func (c *Client) Webhooks(ctx context.Context, req *WebhooksRequest) (*WebhooksR
 func (c *Client) WebhookCreate(ctx context.Context, req *CreateWebhookRequest) (*Webhook, error) {
 	res := new(Webhook)
 
-	return res, c.postJSON(ctx, "/webhooks/", req, res)
+	return res, c.postJSON(ctx, "WebhookCreate", "/webhooks/", req, res)
 }
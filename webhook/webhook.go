@@ -0,0 +1,184 @@
+// Package webhook receives the webhook deliveries sent by Eventbrite and dispatches them to
+// callbacks registered per action, optionally resolving the object the delivery refers to.
+//
+//	h := webhook.NewHandler(clnt)
+//	h.HandleOrder(webhook.ActionOrderPlaced, func(ctx context.Context, p *webhook.Payload, o *eventbrite.Order) error {
+//		// handle me
+//		return nil
+//	})
+//	http.Handle("/eventbrite", h)
+//
+// https://www.eventbrite.com/developer/v3/api_overview/webhooks/
+package webhook
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/apzuk3/go-eventbrite"
+
+	"golang.org/x/net/context"
+)
+
+// Actions triggering a webhook delivery
+const (
+	ActionTest               = "test"
+	ActionAttendeeCheckedIn  = "barcode.checked_in"
+	ActionAttendeeCheckedOut = "barcode.un_checked_in"
+	ActionAttendeeUpdated    = "attendee.updated"
+	ActionEventCreated       = "event.created"
+	ActionEventPublished     = "event.published"
+	ActionEventUnpublished   = "event.unpublished"
+	ActionEventUpdated       = "event.updated"
+	ActionOrderPlaced        = "order.placed"
+	ActionOrderRefunded      = "order.refunded"
+	ActionOrderUpdated       = "order.updated"
+	ActionOrganizerUpdated   = "organizer.updated"
+	ActionTicketClassCreated = "ticket_class.created"
+	ActionTicketClassDeleted = "ticket_class.deleted"
+	ActionTicketClassUpdated = "ticket_class.updated"
+	ActionVenueUpdated       = "venue.updated"
+)
+
+// maxPayloadSize bounds the size of a delivery body, deliveries are a few hundred bytes
+const maxPayloadSize = 1 << 20
+
+// Payload is the body of a webhook delivery. It does not contain the object itself but the
+// api_url it can be fetched from.
+type Payload struct {
+	// The webhook configuration which triggered the delivery
+	Config Config `json:"config"`
+	// The API url of the object the action happened on
+	APIURL string `json:"api_url"`
+}
+
+// Config describes the webhook which triggered a delivery
+type Config struct {
+	// The action which triggered the delivery
+	Action string `json:"action"`
+	// The user owning the webhook
+	UserID string `json:"user_id"`
+	// The url the delivery was sent to
+	EndpointURL string `json:"endpoint_url"`
+	// The webhook ID
+	WebhookID string `json:"webhook_id"`
+}
+
+// HandlerFunc handles a webhook delivery
+type HandlerFunc func(ctx context.Context, p *Payload) error
+
+// Handler is an http.Handler decoding webhook deliveries and dispatching them to the callback
+// registered for their action. Deliveries for actions without a callback are acknowledged and
+// dropped. A callback error answers with a 500 status so that Eventbrite delivers it again.
+//
+// Callbacks must be registered before the handler starts serving requests.
+type Handler struct {
+	client   *eventbrite.Client
+	handlers map[string]HandlerFunc
+	// ErrorLog receives the errors returned by callbacks. They are discarded when nil
+	ErrorLog func(p *Payload, err error)
+}
+
+// NewHandler returns a Handler resolving the objects of deliveries through client. The client
+// may be nil if only untyped callbacks registered with Handle are used.
+func NewHandler(client *eventbrite.Client) *Handler {
+	return &Handler{
+		client:   client,
+		handlers: map[string]HandlerFunc{},
+	}
+}
+
+// Handle registers fn as the callback of the given action
+func (h *Handler) Handle(action string, fn HandlerFunc) {
+	h.handlers[action] = fn
+}
+
+// HandleOrder registers fn as the callback of the given action, the order the delivery refers
+// to is fetched before calling fn
+func (h *Handler) HandleOrder(action string, fn func(ctx context.Context, p *Payload, o *eventbrite.Order) error) {
+	h.Handle(action, func(ctx context.Context, p *Payload) error {
+		o := new(eventbrite.Order)
+		if err := h.resolve(ctx, p, o); err != nil {
+			return err
+		}
+		return fn(ctx, p, o)
+	})
+}
+
+// HandleEvent registers fn as the callback of the given action, the event the delivery refers
+// to is fetched before calling fn
+func (h *Handler) HandleEvent(action string, fn func(ctx context.Context, p *Payload, e *eventbrite.Event) error) {
+	h.Handle(action, func(ctx context.Context, p *Payload) error {
+		e := new(eventbrite.Event)
+		if err := h.resolve(ctx, p, e); err != nil {
+			return err
+		}
+		return fn(ctx, p, e)
+	})
+}
+
+// HandleAttendee registers fn as the callback of the given action, the attendee the delivery
+// refers to is fetched before calling fn
+func (h *Handler) HandleAttendee(action string, fn func(ctx context.Context, p *Payload, a *eventbrite.Attendee) error) {
+	h.Handle(action, func(ctx context.Context, p *Payload) error {
+		a := new(eventbrite.Attendee)
+		if err := h.resolve(ctx, p, a); err != nil {
+			return err
+		}
+		return fn(ctx, p, a)
+	})
+}
+
+// ServeHTTP decodes the delivery and calls the callback registered for its action
+func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		w.Header().Set("Allow", http.MethodPost)
+		http.Error(w, http.StatusText(http.StatusMethodNotAllowed), http.StatusMethodNotAllowed)
+		return
+	}
+
+	p, err := Decode(r.Body)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	fn, ok := h.handlers[p.Config.Action]
+	if !ok {
+		w.WriteHeader(http.StatusOK)
+		return
+	}
+
+	if err := fn(r.Context(), p); err != nil {
+		if h.ErrorLog != nil {
+			h.ErrorLog(p, err)
+		}
+		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+		return
+	}
+	w.WriteHeader(http.StatusOK)
+}
+
+// Decode reads a webhook delivery payload from r
+func Decode(r io.Reader) (*Payload, error) {
+	p := new(Payload)
+	if err := json.NewDecoder(io.LimitReader(r, maxPayloadSize)).Decode(p); err != nil {
+		return nil, fmt.Errorf("webhook: invalid payload: %s", err)
+	}
+	if p.Config.Action == "" {
+		return nil, fmt.Errorf("webhook: invalid payload: missing action")
+	}
+	return p, nil
+}
+
+func (h *Handler) resolve(ctx context.Context, p *Payload, v interface{}) error {
+	if h.client == nil {
+		return fmt.Errorf("webhook: no client to resolve %s", p.APIURL)
+	}
+	if p.APIURL == "" {
+		return fmt.Errorf("webhook: %s delivery without api_url", p.Config.Action)
+	}
+	return h.client.Resolve(ctx, p.APIURL, v)
+}
@@ -0,0 +1,193 @@
+package webhook
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/apzuk3/go-eventbrite"
+	"github.com/apzuk3/go-eventbrite/eventbritetest"
+
+	"golang.org/x/net/context"
+)
+
+func delivery(action, apiURL string) string {
+	return fmt.Sprintf(`{"config":{"action":%q,"user_id":"1","webhook_id":"9"},"api_url":%q}`, action, apiURL)
+}
+
+func TestHandlerDispatch(t *testing.T) {
+	tests := []struct {
+		name       string
+		method     string
+		body       string
+		wantStatus int
+		// the action seen by the callback, empty when it is not called
+		wantAction string
+		wantLog    bool
+	}{
+		{
+			name:       "registered action",
+			body:       delivery(ActionOrderPlaced, "https://www.eventbriteapi.com/v3/orders/1/"),
+			wantStatus: http.StatusOK,
+			wantAction: ActionOrderPlaced,
+		},
+		{
+			name:       "unknown action",
+			body:       delivery(ActionVenueUpdated, "https://www.eventbriteapi.com/v3/venues/1/"),
+			wantStatus: http.StatusOK,
+		},
+		{
+			name:       "callback error",
+			body:       delivery(ActionTest, ""),
+			wantStatus: http.StatusInternalServerError,
+			wantAction: ActionTest,
+			wantLog:    true,
+		},
+		{
+			name:       "malformed payload",
+			body:       `{"config":`,
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name:       "missing action",
+			body:       `{"config":{},"api_url":"https://www.eventbriteapi.com/v3/orders/1/"}`,
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name:       "not a post",
+			method:     http.MethodGet,
+			wantStatus: http.StatusMethodNotAllowed,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var action string
+			var logged error
+			h := NewHandler(nil)
+			h.ErrorLog = func(p *Payload, err error) { logged = err }
+			h.Handle(ActionOrderPlaced, func(ctx context.Context, p *Payload) error {
+				action = p.Config.Action
+				return nil
+			})
+			h.Handle(ActionTest, func(ctx context.Context, p *Payload) error {
+				action = p.Config.Action
+				return errors.New("unavailable")
+			})
+
+			method := tt.method
+			if method == "" {
+				method = http.MethodPost
+			}
+			w := httptest.NewRecorder()
+			h.ServeHTTP(w, httptest.NewRequest(method, "/eventbrite", strings.NewReader(tt.body)))
+
+			if w.Code != tt.wantStatus {
+				t.Errorf("status = %d, want %d", w.Code, tt.wantStatus)
+			}
+			if action != tt.wantAction {
+				t.Errorf("callback saw action %q, want %q", action, tt.wantAction)
+			}
+			if (logged != nil) != tt.wantLog {
+				t.Errorf("ErrorLog got %v, want a log: %v", logged, tt.wantLog)
+			}
+		})
+	}
+}
+
+func TestHandlerResolve(t *testing.T) {
+	srv := eventbritetest.NewServer("token")
+	defer srv.Close()
+	clnt, err := srv.Client()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	eventID := srv.AddEvent(eventbrite.Event{})
+	orderID := srv.AddOrder(eventbrite.Order{EventID: eventID})
+	attendeeID := srv.AddAttendee(eventbrite.Attendee{EventID: eventID, OrderID: orderID})
+
+	var resolved string
+	h := NewHandler(clnt)
+	h.HandleOrder(ActionOrderPlaced, func(ctx context.Context, p *Payload, o *eventbrite.Order) error {
+		resolved = "order " + o.ID
+		return nil
+	})
+	h.HandleEvent(ActionEventPublished, func(ctx context.Context, p *Payload, e *eventbrite.Event) error {
+		resolved = "event " + e.Id
+		return nil
+	})
+	h.HandleAttendee(ActionAttendeeUpdated, func(ctx context.Context, p *Payload, a *eventbrite.Attendee) error {
+		resolved = "attendee " + a.ID
+		return nil
+	})
+	var logged error
+	h.ErrorLog = func(p *Payload, err error) { logged = err }
+
+	tests := []struct {
+		name       string
+		body       string
+		wantStatus int
+		want       string
+		wantErr    string
+	}{
+		{
+			name:       "order",
+			body:       delivery(ActionOrderPlaced, srv.BaseURL()+"/orders/"+orderID+"/"),
+			wantStatus: http.StatusOK,
+			want:       "order " + orderID,
+		},
+		{
+			name:       "event",
+			body:       delivery(ActionEventPublished, srv.BaseURL()+"/events/"+eventID+"/"),
+			wantStatus: http.StatusOK,
+			want:       "event " + eventID,
+		},
+		{
+			name:       "attendee",
+			body:       delivery(ActionAttendeeUpdated, srv.BaseURL()+"/events/"+eventID+"/attendees/"+attendeeID+"/"),
+			wantStatus: http.StatusOK,
+			want:       "attendee " + attendeeID,
+		},
+		{
+			name:       "missing object",
+			body:       delivery(ActionOrderPlaced, srv.BaseURL()+"/orders/404/"),
+			wantStatus: http.StatusInternalServerError,
+			wantErr:    "NOT_FOUND",
+		},
+		{
+			name:       "foreign host",
+			body:       delivery(ActionOrderPlaced, "https://attacker.example.com/v3/orders/"+orderID+"/"),
+			wantStatus: http.StatusInternalServerError,
+			wantErr:    "is not an url of",
+		},
+		{
+			name:       "missing api_url",
+			body:       delivery(ActionOrderPlaced, ""),
+			wantStatus: http.StatusInternalServerError,
+			wantErr:    "without api_url",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resolved, logged = "", nil
+
+			w := httptest.NewRecorder()
+			h.ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/eventbrite", strings.NewReader(tt.body)))
+
+			if w.Code != tt.wantStatus {
+				t.Errorf("status = %d, want %d", w.Code, tt.wantStatus)
+			}
+			if resolved != tt.want {
+				t.Errorf("resolved %q, want %q", resolved, tt.want)
+			}
+			if tt.wantErr == "" && logged != nil || tt.wantErr != "" && (logged == nil || !strings.Contains(logged.Error(), tt.wantErr)) {
+				t.Errorf("logged error %v, want %q", logged, tt.wantErr)
+			}
+		})
+	}
+}